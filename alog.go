@@ -2,16 +2,20 @@
 // It also allows one to configure the destination of the logs. The default is stdout.
 // It looks for a logger configuration file alog.conf first in the current directory and then in the directory defined by ALOG_CONF_DIR
 // If it does not find a logger configuration file in any of these locations, then it uses STDOUT as the logging destination
+// and the default log level of TRACE, so that messages at every level are written. The same default applies when alog.conf
+// does not specify a valid logLevel.
 package alog
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
-	"io"
-	"github.com/en-vee/aconf"
+	"sync/atomic"
+	"time"
 )
 
 /*
@@ -19,6 +23,7 @@ log package provides functions to log messages at various log levels :
 TRACE
 DEBUG
 INFO
+NOTICE
 WARNING
 ERROR
 CRITICAL
@@ -26,225 +31,1116 @@ CRITICAL
 
 var (
 	loggerConfigFileName = "alog.conf"
-	logDestination       = os.Stdout
-	logLevel             LogLevel
 )
 
 // LogLevel is the type used to specify the log level
 type LogLevel uint8
 
-// The log level constants specify the log levels which can be accepted
+// The log level constants specify the log levels which can be accepted.
+// NOTICE was inserted between INFO and WARN, so WARN and the levels above it are one higher than in earlier
+// versions; persist levels by name, via MarshalText, rather than by numeric value.
 const (
 	TRACE LogLevel = iota
 	DEBUG
 	INFO
+	// NOTICE is for normal but significant events, as the syslog severity of the same name
+	NOTICE
 	WARN
 	ERROR
 	CRITICAL
+	// OFF is above every level, so that nothing is logged. GetLogLevel reports it while logging is disabled.
+	OFF
 )
 
+// defaultLogLevel is the level used when alog.conf does not specify a valid one
+const defaultLogLevel = TRACE
+
+// DefaultFlags are the output flags Loggers start out with, which timestamp records as 2018/11/07 18:03:25.123456
+const DefaultFlags = log.Ldate | log.Ltime | log.Lmicroseconds
+
 // Logging Function type
-type logFuncType func(LogLevel, string, ...interface{})
+type logFuncType func(*Logger, LogLevel, Fields, string, ...interface{})
+
+// Logger is a levelled logger which holds its own log level, destination and logging functions.
+// Loggers created with New are independent of each other and of the package level functions,
+// which operate on a default Logger configured from alog.conf.
+type Logger struct {
+	*core
+	// name is the component name given to Named, written as "[name] " in text records. It is empty for other Loggers.
+	name string
+}
 
-// Array containing function values which perform the actual logging
-// Initialized with NoOp logger for all log levels except CRITICAL
-var logFuncsSlice = []logFuncType{noOpLogMsg, noOpLogMsg, noOpLogMsg, noOpLogMsg, noOpLogMsg, logMsg}
+// core holds the settings and state of a Logger, which it shares with the Loggers created from it by Named
+type core struct {
+	// mu guards the settings of the core which follow it
+	mu  sync.RWMutex
+	out io.Writer
+	// extra holds the destinations added with AddDestination, which receive the records meeting their threshold along with out
+	extra []destination
+	// destinationSet is whether out was set with SetLogDestination or SetDestinations, which take precedence over the
+	// fileName in alog.conf
+	destinationSet bool
+	level          LogLevel
+	// disabledLevel is the level to restore on Enable, while the Logger is disabled
+	disabledLevel *LogLevel
+	format        Format
+	formatter     Formatter
+	colorMode     ColorMode
+	// colorOn is whether level labels are currently colored, worked out from colorMode and the destination
+	colorOn bool
+	// labels holds the level tags set with SetLevelLabels, or nil for the defaults in logLevelIntToStringMap
+	labels map[LogLevel]string
+	// bareLevels writes the default level tags without their brackets, as set with SetBracketedLevels(false)
+	bareLevels bool
+	// prefix is written between the timestamp and the level tag of text records
+	prefix string
+	// separator is written before the level tag and before the message of text records
+	separator string
+	// includePID and includeHost add the process ID and the hostname to every record
+	includePID  bool
+	includeHost bool
+	callerInfo  bool
+	// errorChain renders error args with the errors they wrap, as set with SetErrorChain
+	errorChain bool
+	// stackLevel is the level at or above which records carry a stack trace, OFF for none
+	stackLevel LogLevel
+	// file is the log file opened by alog, which is closed by Close, and fileName and fileOpts are what it was opened with
+	file     io.WriteCloser
+	fileName string
+	fileOpts FileOptions
+	// syslog is the connection opened by SetSyslog, which also receives every record
+	syslog levelledWriter
+	// ring keeps the last records, including those below the level, when EnableRingBuffer is on
+	ring *ringBuffer
+	// async is the background writer used when EnableAsync is on
+	async    *asyncWriter
+	overflow OverflowPolicy
+	// dedup collapses repeated records when SetDedup is on
+	dedup *deduper
+	// sampling holds the samplers set with SetSampling, by level
+	sampling map[LogLevel]*sampler
+	// hooks are the functions added with AddHook. The slice is replaced, never modified, so it can be used after mu is released.
+	hooks []hook
+	// redactions are applied, in order, to every message. Like hooks, the slice is replaced, never modified.
+	redactions []redaction
+	// counts holds the number of records written at each level, updated atomically
+	counts [CRITICAL + 1]uint64
+	// flags are the output flags, as defined by the standard library's log package, which control the timestamp of text records
+	flags int
+	// timeFormat is the layout of the timestamp of text records set with SetTimeFormat, which takes precedence over flags
+	timeFormat string
+	// clock returns the time records are stamped with, time.Now unless SetClock changed it
+	clock func() time.Time
+	// noTimestamp leaves the timestamp out of every record, as set with SetTimestamp(false)
+	noTimestamp bool
+	// lineEnding terminates every record, "\n" unless SetLineEnding changed it
+	lineEnding string
+	// w is where records are written: the destinations, or the async writer in front of them
+	w io.Writer
+	// writeMu serializes the writes of whole records to w, so that concurrent records are never interleaved
+	writeMu sync.Mutex
+	// fallback writes records to stderr when w fails
+	fallback fallback
+	// Slice containing function values which perform the actual logging, indexed by log level
+	logFuncs []logFuncType
+}
 
+// std is the default Logger used by the package level functions.
+// It writes to stdout independently of the standard library's global logger, and starts out at defaultLogLevel,
+// until alog.conf is applied.
+var std = newDefaultLogger()
+
+func newDefaultLogger() *Logger {
+	l := &Logger{core: &core{
+		out:        os.Stdout,
+		w:          os.Stdout,
+		flags:      log.LstdFlags,
+		separator:  defaultSeparator,
+		lineEnding: "\n",
+		clock:      time.Now,
+		stackLevel: OFF,
+		logFuncs:   make([]logFuncType, CRITICAL+1),
+	}}
+	l.setLogLevel(defaultLogLevel)
+	return l
+}
+
+// New creates a Logger which writes to w and logs messages at or above level
+func New(w io.Writer, level LogLevel) *Logger {
+	l := &Logger{core: &core{
+		out:        w,
+		w:          w,
+		flags:      DefaultFlags,
+		separator:  defaultSeparator,
+		lineEnding: "\n",
+		clock:      time.Now,
+		stackLevel: OFF,
+		logFuncs:   make([]logFuncType, CRITICAL+1),
+	}}
+	l.setLogLevel(level)
+	return l
+}
+
+// logLevelIntToStringMap holds the level tags of text records. The spaces around a tag are written by the record layout.
 var logLevelIntToStringMap = map[LogLevel]string{
-	TRACE:    "[TRACE] ",
-	DEBUG:    "[DEBUG] ",
-	INFO:     "[INFO] ",
-	WARN:     "[WARN] ",
-	ERROR:    "[ERROR] ",
-	CRITICAL: "[CRITICAL] ",
+	TRACE:    "[TRACE]",
+	DEBUG:    "[DEBUG]",
+	INFO:     "[INFO]",
+	NOTICE:   "[NOTICE]",
+	WARN:     "[WARN]",
+	ERROR:    "[ERROR]",
+	CRITICAL: "[CRITICAL]",
+}
+
+// String returns the name of the level, e.g. INFO, or UNKNOWN(n) for a value which is not a valid level
+func (level LogLevel) String() string {
+	if level == OFF {
+		return "OFF"
+	}
+	if label, ok := logLevelIntToStringMap[level]; ok {
+		return strings.Trim(label, "[]")
+	}
+	return fmt.Sprintf("UNKNOWN(%d)", uint8(level))
+}
+
+// MarshalText implements encoding.TextMarshaler, producing the name of the level
+func (level LogLevel) MarshalText() ([]byte, error) {
+	if level > OFF {
+		return nil, &InvalidLogLevelError{got: level}
+	}
+	return []byte(level.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting level names case-insensitively
+func (level *LogLevel) UnmarshalText(text []byte) error {
+	l, err := ParseLevel(string(text))
+	if err != nil {
+		return err
+	}
+	*level = l
+	return nil
 }
 
 var logStringToIntLevelMap = map[string]LogLevel{
-	"TRACE":    0,
-	"DEBUG":    1,
-	"INFO":     2,
-	"WARN":     3,
-	"ERROR":    4,
-	"CRITICAL": 5,
+	"TRACE":    TRACE,
+	"DEBUG":    DEBUG,
+	"INFO":     INFO,
+	"NOTICE":   NOTICE,
+	"WARN":     WARN,
+	"ERROR":    ERROR,
+	"CRITICAL": CRITICAL,
+	"OFF":      OFF,
+	// Aliases, as spelled by syslog
+	"WARNING": WARN,
+	"ERR":     ERROR,
+	"CRIT":    CRITICAL,
 }
 
-type loggerConf struct {
-	fileName string
-	filePath string
-	logLevel LogLevel
+// configSection is the alog block of alog.conf
+type configSection struct {
+	FileName string `hocon:"fileName"`
+	LogLevel string `hocon:"logLevel"`
+	UTC      string `hocon:"utc"`
+	Rotate   string `hocon:"rotate"`
+	MaxSize  string `hocon:"maxSizeMB"`
+	MaxAge   string `hocon:"maxAgeDays"`
+	Compress string `hocon:"compress"`
+	FileMode string `hocon:"fileMode"`
+	Append   string `hocon:"append"`
 }
 
-var theConfig loggerConf
+// configFile is the layout of alog.conf
+type configFile struct {
+	Alog configSection `hocon:"alog"`
+}
 
 func init() {
-	// Is alog.conf present in local folder ?
-	// 	If yes,
-	// 	Instantiate an io.Reader using the file name alog.conf
-	// If not, then check if ALOG_CONF_DIR environment variable has been defined.
-	// 		If yes, then attempt to create an io.Reader from alog.conf.
-	// If reader is still nil, then just set destination output to stdout
-
-	var ok bool
-	configParser := &aconf.HoconParser{}
-	alogConfig := &struct {
-		Alog struct {
-			FileName string `hocon:"fileName"`
-			LogLevel string `hocon:"logLevel"`
-		} `hocon:"alog"`
-	}{}
-
-	// Select logger config file, giving priority to local alog.conf
-	if logConfDir, ok := os.LookupEnv("ALOG_CONF_DIR"); ok && !fileExists("alog.conf") {
-		loggerConfigFileName = fmt.Sprintf("%s%c%s", logConfDir, os.PathSeparator, "alog.conf")
-	}
-
-	if reader, err := os.Open(loggerConfigFileName); err == nil {
-		if err := configParser.Parse(reader, alogConfig); err == nil {
-			if len(alogConfig.Alog.FileName) != 0 {
-				logDestination, err = os.OpenFile(alogConfig.Alog.FileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "alog: unable to open log file : "+alogConfig.Alog.FileName+". Error : "+err.Error()+"\n")
-					fmt.Fprintf(os.Stderr, "alog: using STDOUT for logging\n")
-					logDestination = os.Stdout
-				}
-			}
+	SetLogLevel(defaultLogLevel)
+	SetFlags(DefaultFlags)
+	initErr = autoInit()
+}
 
-			if logLevel, ok = logStringToIntLevelMap[alogConfig.Alog.LogLevel]; !ok {
-				fmt.Println("alog: invalid log level specified :", alogConfig.Alog.LogLevel, "Using default level of TRACE")
+// autoInit loads the logger config file and ALOG_LEVEL at startup, unless ALOG_AUTOINIT is set to 0 (or false).
+// Programs which turn it off can load the configuration explicitly with ReloadConfig, or call Configure.
+func autoInit() error {
+	if s, ok := os.LookupEnv("ALOG_AUTOINIT"); ok {
+		if auto, err := strconv.ParseBool(s); err == nil && !auto {
+			return nil
+		}
+	}
+	return loadConfig()
+}
+
+// initErr is the error, if any, from loading the logger config file at startup
+var initErr error
+
+// InitError returns the error from loading the logger config file at startup, or nil if it was loaded successfully or absent.
+// Programs can use it to detect a misconfigured alog.conf, in which case the defaults are in use.
+func InitError() error {
+	return initErr
+}
+
+// configFileName returns the logger config file to be used, giving priority to alog.conf in the current directory,
+// then to alog.hocon, alog.yaml, alog.yml and alog.json there, and then to the same names in ALOG_CONF_DIR
+func configFileName() string {
+	for _, name := range loggerConfigFileNames {
+		if fileExists(name) {
+			return name
+		}
+	}
+	if logConfDir, ok := os.LookupEnv("ALOG_CONF_DIR"); ok {
+		for _, name := range loggerConfigFileNames {
+			if path := fmt.Sprintf("%s%c%s", logConfDir, os.PathSeparator, name); fileExists(path) {
+				return path
 			}
 		}
+		return fmt.Sprintf("%s%c%s", logConfDir, os.PathSeparator, loggerConfigFileName)
 	}
+	return loggerConfigFileName
+}
+
+// readConfig reads the logger config file, in the syntax given by its extension.
+// If there is no config file, or the name is taken by a directory, it returns an empty config, so that the defaults apply.
+func readConfig() (*configFile, error) {
+	fileName := configFileName()
+	if !fileExists(fileName) {
+		return &configFile{}, nil
+	}
+	reader, err := os.Open(fileName)
+	if err != nil {
+		return &configFile{}, nil
+	}
+	defer reader.Close()
+
+	return parseConfig(reader, configFormatOf(fileName))
+}
 
-	SetLogLevel(logLevel)
-	log.SetOutput(logDestination)
-	//log.SetPrefix(logLevelIntToStringMap[logLevel] + " - ")
-	log.SetFlags(log.Ldate | log.Ltime | log.Lmicroseconds)
+// loadConfig reads the logger config file and applies it to the default Logger, followed by the ALOG_LEVEL override.
+// If the config file cannot be parsed, the current configuration is kept.
+func loadConfig() error {
+	alogConfig, err := readConfig()
+	if err == nil {
+		applyConfig(&alogConfig.Alog)
+	} else {
+		fmt.Fprintf(os.Stderr, "alog: unable to parse config file : %s. Error : %v\n", configFileName(), err)
+	}
+	ApplyEnvLevel()
+	return err
 }
 
+// applyConfig applies the alog block of the config file to the default Logger.
+// Invalid values are reported on stderr and replaced by their defaults.
+func applyConfig(conf *configSection) {
+	c := conf.config()
+	std.mu.RLock()
+	c.Flags, c.Format = std.flags&^log.LUTC, std.format
+	destination, destinationSet := std.out, std.destinationSet
+	std.mu.RUnlock()
+
+	if destinationSet {
+		if len(c.FileName) != 0 {
+			defer std.DebugLn("alog: log file", c.FileName, "overridden by the destination set with SetLogDestination")
+		}
+		c.Destination, c.FileName = destination, ""
+	}
+
+	if err := std.Configure(c); err != nil {
+		fmt.Fprintf(os.Stderr, "alog: unable to open log file : %s. Error : %v\n", c.FileName, err)
+		fmt.Fprintf(os.Stderr, "alog: using STDOUT for logging\n")
+		c.FileName = ""
+		std.Configure(c)
+	}
+}
+
+// config converts a section of the config file to a Config, leaving its Flags and Format unset.
+// Invalid values are reported on stderr and replaced by their defaults.
+func (conf *configSection) config() Config {
+	var err error
+	var logLevel LogLevel
+	var utc bool
+	var fileOpts FileOptions
+
+	if len(conf.Rotate) != 0 {
+		if fileOpts.Rotation, err = ParseRotation(conf.Rotate); err != nil {
+			fmt.Fprintf(os.Stderr, "alog: invalid rotate value specified : %s. Log file will not be rotated\n", conf.Rotate)
+		}
+	}
+
+	if len(conf.MaxSize) != 0 {
+		if mb, err := strconv.ParseUint(conf.MaxSize, 10, 32); err != nil {
+			fmt.Fprintf(os.Stderr, "alog: invalid maxSizeMB value specified : %s. Log file will not be rotated by size\n", conf.MaxSize)
+		} else {
+			fileOpts.MaxSize = int64(mb) << 20
+		}
+	}
+
+	if len(conf.MaxAge) != 0 {
+		if days, err := strconv.ParseUint(conf.MaxAge, 10, 16); err != nil {
+			fmt.Fprintf(os.Stderr, "alog: invalid maxAgeDays value specified : %s. Rotated files will be kept\n", conf.MaxAge)
+		} else {
+			fileOpts.MaxAgeDays = int(days)
+		}
+	}
+
+	if len(conf.Compress) != 0 {
+		if fileOpts.Compress, err = strconv.ParseBool(conf.Compress); err != nil {
+			fmt.Fprintf(os.Stderr, "alog: invalid compress value specified : %s. Rotated files will not be compressed\n", conf.Compress)
+		}
+	}
+
+	if len(conf.FileMode) != 0 {
+		if fileOpts.Mode, err = ParseFileMode(conf.FileMode); err != nil {
+			fmt.Fprintf(os.Stderr, "alog: invalid fileMode value specified : %s. Using default of %#o\n", conf.FileMode, defaultFileMode)
+		}
+	}
+
+	if len(conf.Append) != 0 {
+		if appendFile, err := strconv.ParseBool(conf.Append); err != nil {
+			fmt.Fprintf(os.Stderr, "alog: invalid append value specified : %s. Appending to the log file\n", conf.Append)
+		} else {
+			fileOpts.Truncate = !appendFile
+		}
+	}
+
+	logLevel = defaultLogLevel
+	if len(conf.LogLevel) != 0 {
+		if logLevel, err = ParseLevel(conf.LogLevel); err != nil {
+			logLevel = defaultLogLevel
+			fmt.Fprintf(os.Stderr, "alog: %v. Using default level of %v\n", err, defaultLogLevel)
+		}
+	}
+
+	if len(conf.UTC) != 0 {
+		if utc, err = strconv.ParseBool(conf.UTC); err != nil {
+			fmt.Fprintf(os.Stderr, "alog: invalid utc value specified : %s. Using local time\n", conf.UTC)
+		}
+	}
+
+	c := Config{Level: logLevel, File: fileOpts, UTC: utc}
+	if len(conf.FileName) != 0 {
+		c.FileName = expandFileName(conf.FileName)
+	}
+	return c
+}
+
+// ReloadConfig re-reads the logger config file, using the same selection as at startup (alog.conf in the current directory,
+// then in ALOG_CONF_DIR), and re-applies it to the default Logger. It is safe to call concurrently with logging.
+// If the config file cannot be parsed, the error is returned and the current configuration is kept.
+func ReloadConfig() error {
+	return loadConfig()
+}
+
+// ParseLevel returns the LogLevel named by s, e.g. "INFO". The match is case-insensitive.
+// The syslog spellings WARNING, ERR and CRIT are accepted for WARN, ERROR and CRITICAL.
+// If s does not name a level, the error is an *InvalidLogLevelError.
+func ParseLevel(s string) (LogLevel, error) {
+	level, ok := logStringToIntLevelMap[strings.ToUpper(s)]
+	if !ok {
+		return level, &InvalidLogLevelError{input: s}
+	}
+	return level, nil
+}
+
+// ApplyEnvLevel sets the level of the default Logger from the ALOG_LEVEL environment variable, if it is defined.
+// ALOG_LEVEL takes priority over the logLevel in alog.conf and is applied at startup.
+// An invalid value is reported on stderr and returned, leaving the current level unchanged.
+func ApplyEnvLevel() error {
+	s, ok := os.LookupEnv("ALOG_LEVEL")
+	if !ok {
+		return nil
+	}
+	level, err := ParseLevel(s)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "alog: invalid ALOG_LEVEL specified : %s. Keeping level %v\n", s, GetLogLevel())
+		return err
+	}
+	return SetLogLevel(level)
+}
+
+// fileExists reports whether filename exists and is not a directory.
+// Any error from os.Stat, not only a missing file, is treated as the file not existing.
 func fileExists(filename string) bool {
 	info, err := os.Stat(filename)
-	if os.IsNotExist(err) {
+	if err != nil {
 		return false
 	}
 	return !info.IsDir()
 }
 
-var singleTon sync.Once
-
 // InvalidLogLevelError is used to indicate invalid log level
 type InvalidLogLevelError struct {
 	got LogLevel
+	// input is the offending level name, when the error comes from parsing a string
+	input string
 }
 
 // Stringer interface method(s)
 func (ie *InvalidLogLevelError) String() string {
-	return fmt.Sprintf("%d", ie.got)
+	if len(ie.input) != 0 {
+		return ie.input
+	}
+	return ie.got.String()
 }
 
 // error interface method
 func (ie *InvalidLogLevelError) Error() string {
-	return fmt.Sprintf("Invalid Log Level : %v. Valid Values are TRACE|DEBUG|INFO|WARN|ERROR|CRITICAL", ie.got)
+	return fmt.Sprintf("Invalid Log Level : %v. Valid Values are TRACE|DEBUG|INFO|NOTICE|WARN|ERROR|CRITICAL|OFF", ie.String())
 }
 
-func setLogLevel(level LogLevel) {
-		if level > CRITICAL {
-			level = CRITICAL
-		}
+func (l *Logger) setLogLevel(level LogLevel) {
+	if level > OFF {
+		level = OFF
+	}
 
-		for i := range logFuncsSlice {
-			logFuncsSlice[i] = noOpLogMsg
-		}
+	// Suppressed levels are nil, unless the ring buffer is on, in which case they are only recorded in it
+	var suppressed logFuncType
+	if l.ring != nil {
+		suppressed = (*Logger).ringMsg
+	}
+	for i := range l.logFuncs {
+		l.logFuncs[i] = suppressed
+	}
 
-		// Level     => 0 1 2 3 4 5
-		// Set/Unset => O O O X X X
-		// For example, If level = 0, which is TRACE, then select slice from 0 through len(logFuncs)
-		// If level = 1, which is DEBUG, then select slice from 1 through len(logFuncs)
-		p := logFuncsSlice[level:]
+	// Level     => 0 1 2 3 4 5
+	// Set/Unset => O O O X X X
+	// For example, If level = 0, which is TRACE, then select slice from 0 through len(logFuncs)
+	// If level = 1, which is DEBUG, then select slice from 1 through len(logFuncs)
+	// If level = OFF, the slice is empty and every level stays nil, i.e. suppressed
+	p := l.logFuncs[level:]
 
-		for i := range p {
-			p[i] = logMsg
-		}
+	for i := range p {
+		p[i] = (*Logger).logMsg
+	}
+	l.level = level
 }
 
-func SetLogLevel(level LogLevel) error {
+// SetLogLevel sets the level at or above which the Logger writes messages. OFF turns off all logging.
+func (l *Logger) SetLogLevel(level LogLevel) error {
 
-	if level > CRITICAL {
-		return &InvalidLogLevelError{level}
+	if level > OFF {
+		return &InvalidLogLevelError{got: level}
 	}
 
-	setLogLevel(level)
+	l.mu.Lock()
+	l.setLogLevel(level)
+	l.disabledLevel = nil
+	l.mu.Unlock()
 
 	return nil
 }
 
+// Disable turns off all logging by the Logger, even at CRITICAL, until Enable or SetLogLevel is called.
+// While disabled, GetLogLevel reports OFF.
+func (l *Logger) Disable() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.disabledLevel == nil {
+		level := l.level
+		l.disabledLevel = &level
+	}
+	l.setLogLevel(OFF)
+}
+
+// Enable turns logging by the Logger back on at the level it had when Disable was called. It has no effect if the Logger is not disabled.
+func (l *Logger) Enable() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.disabledLevel != nil {
+		l.setLogLevel(*l.disabledLevel)
+		l.disabledLevel = nil
+	}
+}
+
+// Disable turns off all logging by the default Logger
+func Disable() {
+	std.Disable()
+}
+
+// Enable turns logging by the default Logger back on at the level it had when Disable was called
+func Enable() {
+	std.Enable()
+}
+
+// GetLogLevel returns the level at or above which the Logger currently writes messages
+func (l *Logger) GetLogLevel() LogLevel {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.level
+}
+
+// SetLogLevel sets the level of the default Logger
+func SetLogLevel(level LogLevel) error {
+	return std.SetLogLevel(level)
+}
+
+// IsEnabled reports whether the Logger writes messages at the given level.
+// It can be used to guard expensive computation of log arguments.
+func (l *Logger) IsEnabled(level LogLevel) bool {
+	return level >= l.GetLogLevel() && level < OFF
+}
+
+// ShouldLog reports whether a message at level would pass the level threshold of the Logger, and so be written, as
+// Trace/Debug/... and LogBytes decide it. It is the same check as IsEnabled, for generic code which branches on a level value,
+// e.g. to run an expensive diagnostic only when its output would be kept. Sampling, if on, may still drop the message.
+func (l *Logger) ShouldLog(level LogLevel) bool { return l.IsEnabled(level) }
+
+// IsTraceEnabled reports whether TRACE messages are written by the Logger
+func (l *Logger) IsTraceEnabled() bool { return l.IsEnabled(TRACE) }
+
+// IsDebugEnabled reports whether DEBUG messages are written by the Logger
+func (l *Logger) IsDebugEnabled() bool { return l.IsEnabled(DEBUG) }
+
+// IsInfoEnabled reports whether INFO messages are written by the Logger
+func (l *Logger) IsInfoEnabled() bool { return l.IsEnabled(INFO) }
+
+// IsNoticeEnabled reports whether NOTICE messages are written by the Logger
+func (l *Logger) IsNoticeEnabled() bool { return l.IsEnabled(NOTICE) }
+
+// IsWarnEnabled reports whether WARN messages are written by the Logger
+func (l *Logger) IsWarnEnabled() bool { return l.IsEnabled(WARN) }
+
+// IsErrorEnabled reports whether ERROR messages are written by the Logger
+func (l *Logger) IsErrorEnabled() bool { return l.IsEnabled(ERROR) }
+
+// IsEnabled reports whether the default Logger writes messages at the given level
+func IsEnabled(level LogLevel) bool { return std.IsEnabled(level) }
+
+// ShouldLog reports whether a message at level would be written by the default Logger, see (*Logger).ShouldLog
+func ShouldLog(level LogLevel) bool { return std.ShouldLog(level) }
+
+// IsTraceEnabled reports whether TRACE messages are written by the default Logger
+func IsTraceEnabled() bool { return std.IsTraceEnabled() }
+
+// IsDebugEnabled reports whether DEBUG messages are written by the default Logger
+func IsDebugEnabled() bool { return std.IsDebugEnabled() }
+
+// IsInfoEnabled reports whether INFO messages are written by the default Logger
+func IsInfoEnabled() bool { return std.IsInfoEnabled() }
+
+// IsNoticeEnabled reports whether NOTICE messages are written by the default Logger
+func IsNoticeEnabled() bool { return std.IsNoticeEnabled() }
+
+// IsWarnEnabled reports whether WARN messages are written by the default Logger
+func IsWarnEnabled() bool { return std.IsWarnEnabled() }
+
+// IsErrorEnabled reports whether ERROR messages are written by the default Logger
+func IsErrorEnabled() bool { return std.IsErrorEnabled() }
+
+// GetLogLevel returns the current level of the default Logger
+func GetLogLevel() LogLevel {
+	return std.GetLogLevel()
+}
+
+// SetLogDestination switches the output of the Logger to w.
+// It may be called any number of times, each call replacing the previous destination.
+// Destinations added with AddDestination keep receiving records.
+// The destination takes precedence over the fileName in alog.conf, whether the config is loaded before or after it,
+// and a DEBUG record notes when the log file is overridden.
+func (l *Logger) SetLogDestination(w io.Writer) {
+	l.mu.Lock()
+	var replaced string
+	if l.file != nil {
+		replaced = l.fileName
+	}
+	l.out = w
+	l.destinationSet = true
+	l.updateOutput()
+	l.mu.Unlock()
+
+	if len(replaced) != 0 {
+		l.DebugLn("alog: log file", replaced, "overridden by the destination set with SetLogDestination")
+	}
+}
+
+// SetLogDestination switches the output of the default Logger to w
 func SetLogDestination(w io.Writer) {
-	singleTon.Do(func(){
-		log.SetOutput(w)
-	})
+	std.SetLogDestination(w)
 }
 
-// noOpLogMsg is just an empty (No Operation) implementation which does nothing.
-// It is needed with full signature so that it can be set into a function value which is compatible with the actual log.Printf method
-func noOpLogMsg(level LogLevel, msg string, objs ...interface{}) {}
+// GetLogDestination returns the main destination of the Logger: the writer given to New or SetLogDestination,
+// or the log file opened by alog. Destinations added with AddDestination are not included.
+func (l *Logger) GetLogDestination() io.Writer {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.out
+}
 
-// logMsg performs actual logging to a destination when used as a function value for a specific log level
-func logMsg(level LogLevel, msg string, objs ...interface{}) {
+// GetLogDestination returns the main destination of the default Logger, which is os.Stdout unless alog.conf or
+// SetLogDestination changed it
+func GetLogDestination() io.Writer {
+	return std.GetLogDestination()
+}
 
-	var sb strings.Builder
+// logFunc returns the function value which logs messages at level, guarded against concurrent SetLogLevel calls.
+// It returns nil when messages at level are suppressed, so that callers return straight away without handing on their args.
+func (l *Logger) logFunc(level LogLevel) logFuncType {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if int(level) >= len(l.logFuncs) {
+		return nil
+	}
+	return l.logFuncs[level]
+}
 
-	sb.WriteString("- ")
-	sb.WriteString(logLevelIntToStringMap[level])
-	sb.WriteString("- ")
-	sb.WriteString(msg)
+// SetFlags sets the output flags of the Logger, as defined by the standard library's log package (log.Ldate, log.Ltime, log.LUTC, ...).
+// The default is DefaultFlags. log.Lshortfile and log.Llongfile are ignored, use SetCallerInfo instead.
+func (l *Logger) SetFlags(flag int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.flags = flag
+}
+
+// GetFlags returns the output flags of the Logger
+func (l *Logger) GetFlags() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.flags
+}
 
-	m := sb.String()
+// defaultSeparator is the separator of the default text layout, e.g. 2018/11/07 18:03:25.123456 - [INFO] - msg
+const defaultSeparator = " - "
 
-	if len(objs) > 0 {
-		log.Printf(m, objs...)
+// SetSeparator replaces the " - " delimiters between the timestamp, the level tag and the message of text records with sep,
+// so SetSeparator(" | ") gives "... 18:03:25.123456 | [INFO] | msg" and SetSeparator("\t") gives tab separated records.
+// A record which starts with the level tag, e.g. with SetFlags(0), starts with sep stripped of its leading spaces, e.g. "| [INFO] | msg".
+func (l *Logger) SetSeparator(sep string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.separator = sep
+}
+
+// SetSeparator replaces the " - " delimiters in the text records of the default Logger with sep
+func SetSeparator(sep string) {
+	std.SetSeparator(sep)
+}
+
+// SetPrefix sets a prefix, e.g. "[auth] ", which is written verbatim after the timestamp of every text record of the Logger,
+// before the level tag. Each Logger created with New has its own prefix, which its Named sub-loggers share.
+// The default is no prefix.
+func (l *Logger) SetPrefix(p string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prefix = p
+}
+
+// SetPrefix sets the prefix of the text records of the default Logger
+func SetPrefix(p string) {
+	std.SetPrefix(p)
+}
+
+// Named returns a sub-logger which tags its records with name, e.g. "[db] " in text records and a "logger" field in
+// JSON and logfmt records. Names of nested sub-loggers are joined with dots, so Named("db").Named("pool") is tagged "[db.pool] ".
+// The sub-logger shares the level, destinations and every other setting of l, so changing them on either affects both.
+func (l *Logger) Named(name string) *Logger {
+	if len(l.name) != 0 {
+		name = l.name + "." + name
+	}
+	return &Logger{core: l.core, name: name}
+}
+
+// Named returns a sub-logger of the default Logger which tags its records with name
+func Named(name string) *Logger {
+	return std.Named(name)
+}
+
+// SetTimeFormat makes the Logger timestamp text records with layout, a time.Time.Format reference layout such as
+// time.RFC3339Nano, instead of according to its flags. log.LUTC in the flags, or SetUTC, still selects UTC.
+// An empty layout goes back to the timestamp given by the flags.
+func (l *Logger) SetTimeFormat(layout string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.timeFormat = layout
+}
+
+// SetTimeFormat makes the default Logger timestamp text records with layout
+func SetTimeFormat(layout string) {
+	std.SetTimeFormat(layout)
+}
+
+// SetTimestamp turns on or off the timestamp of every record of the Logger, which is on by default.
+// Without it, text records start with the level tag, e.g. "[INFO] - msg", and JSON and logfmt records have no time field.
+// This suits processes run under systemd or Docker, which timestamp their output themselves.
+func (l *Logger) SetTimestamp(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.noTimestamp = !enabled
+}
+
+// SetTimestamp turns on or off the timestamp of every record of the default Logger
+func SetTimestamp(enabled bool) {
+	std.SetTimestamp(enabled)
+}
+
+// SetUTC switches the timestamps of the Logger between UTC (true) and local time (false) by adding or removing log.LUTC from its flags
+func (l *Logger) SetUTC(utc bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if utc {
+		l.flags |= log.LUTC
 	} else {
-		log.Printf(m)
+		l.flags &^= log.LUTC
+	}
+}
+
+// SetUTC switches the timestamps of the default Logger between UTC and local time.
+// It can also be configured with the utc key in alog.conf. The default is local time.
+func SetUTC(utc bool) {
+	std.SetUTC(utc)
+}
+
+// SetFlags sets the output flags of the default Logger
+func SetFlags(flag int) {
+	std.SetFlags(flag)
+}
+
+// GetFlags returns the output flags of the default Logger
+func GetFlags() int {
+	return std.GetFlags()
+}
+
+// logMsg performs actual logging to a destination when used as a function value for a specific log level
+func (l *Logger) logMsg(level LogLevel, fields Fields, msg string, objs ...interface{}) {
+
+	s := l.settings(level)
+	if !s.sampled() {
+		return
+	}
+
+	var callSite string
+	if s.callerInfo {
+		callSite = caller()
 	}
-	//log.Printf("%-12s - %s\n", logLevelIntToStringMap[level], msg)
+	if s.stackTrace {
+		fields = fields.with(stackField, stack())
+	}
+
+	if len(objs) != 0 && s.errorChain {
+		objs = withErrorChains(objs)
+	}
+
+	l.write(s, level, fields, callSite, expandMsg(msg, objs...), l.writeRecord)
 }
 
-func Trace(msg string, objs ...interface{}) {
-	var level LogLevel = TRACE
-	// Select Function based on level
-	logFunc := logFuncsSlice[level]
-	logFunc(level, msg, objs...)
+// recordSettings is a snapshot of the settings which shape a record, taken once per record under a single read lock,
+// so that a record is never rendered from a mix of the settings before and after a concurrent setter
+type recordSettings struct {
+	sampler     *sampler
+	callerInfo  bool
+	stackTrace  bool
+	errorChain  bool
+	dedup       *deduper
+	hooks       []hook
+	redactions  []redaction
+	format      Format
+	formatter   Formatter
+	color       bool
+	label       string
+	flags       int
+	timeFormat  string
+	stamp       bool
+	clock       func() time.Time
+	prefix      string
+	sep         string
+	ending      string
+	includePID  bool
+	includeHost bool
+}
+
+// settings returns the snapshot of the settings for a record at level
+func (l *Logger) settings(level LogLevel) recordSettings {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	label := logLevelIntToStringMap[level]
+	if l.labels != nil {
+		label = l.labels[level]
+	} else if l.bareLevels {
+		label = level.String()
+	}
+	return recordSettings{
+		sampler:     l.sampling[level],
+		callerInfo:  l.callerInfo,
+		stackTrace:  level >= l.stackLevel,
+		errorChain:  l.errorChain,
+		dedup:       l.dedup,
+		hooks:       l.hooks,
+		redactions:  l.redactions,
+		format:      l.format,
+		formatter:   l.formatter,
+		color:       l.colorOn,
+		label:       label,
+		flags:       l.flags,
+		timeFormat:  l.timeFormat,
+		stamp:       !l.noTimestamp,
+		clock:       l.clock,
+		prefix:      l.prefix,
+		sep:         l.separator,
+		ending:      l.lineEnding,
+		includePID:  l.includePID,
+		includeHost: l.includeHost,
+	}
+}
+
+// sampled reports whether the record passes the sampler set with SetSampling, if any
+func (s recordSettings) sampled() bool {
+	return s.sampler == nil || s.sampler.sample()
+}
+
+// now returns the current time, in UTC if the Logger is set to UTC timestamps
+func (s recordSettings) now() time.Time {
+	now := s.clock()
+	if s.flags&log.LUTC != 0 {
+		now = now.UTC()
+	}
+	return now
+}
+
+// write redacts an already expanded message, then writes the record with out and runs the hooks, unless it is collapsed by deduplication
+func (l *Logger) write(s recordSettings, level LogLevel, fields Fields, callSite string, msg string, out func(LogLevel, []byte)) {
+
+	msg = redact(s.redactions, msg)
+
+	if s.dedup != nil && !s.dedup.admit(l, level, fields, callSite, msg) {
+		return
+	}
+
+	l.emit(s, level, fields, callSite, msg, out)
+	runHooks(s.hooks, level, msg)
+}
+
+// emit formats a record with an already expanded message and writes it with out, normally writeRecord.
+// callSite is empty unless caller info is enabled.
+func (l *Logger) emit(s recordSettings, level LogLevel, fields Fields, callSite string, msg string, out func(LogLevel, []byte)) {
+
+	atomic.AddUint64(&l.counts[level], 1)
+
+	l.render(s, level, fields, callSite, msg, out)
+}
+
+// render formats a record with an already expanded message and passes it, including its trailing newline, to out
+func (l *Logger) render(s recordSettings, level LogLevel, fields Fields, callSite string, msg string, out func(level LogLevel, b []byte)) {
+
+	if s.formatter != nil {
+		if len(l.name) != 0 {
+			fields = fields.with("logger", l.name)
+		}
+		fields = withProcessFields(fields, s.includePID, s.includeHost)
+		l.logFormatted(s, level, fields, msg, out)
+		return
+	}
+
+	if s.format == FormatJSON || s.format == FormatLogfmt || s.format == FormatGELF {
+		if len(callSite) != 0 {
+			fields = fields.with("caller", callSite)
+		}
+		if len(l.name) != 0 {
+			fields = fields.with("logger", l.name)
+		}
+		fields = withProcessFields(fields, s.includePID, s.includeHost)
+		switch s.format {
+		case FormatJSON:
+			l.logJSON(s, level, fields, msg, out)
+		case FormatLogfmt:
+			l.logLogfmt(s, level, fields, msg, out)
+		default:
+			l.logGELF(s, level, fields, msg, out)
+		}
+		return
+	}
+
+	buf := getBuffer()
+	defer putBuffer(buf)
+	if s.stamp && len(s.timeFormat) != 0 {
+		buf.Write(s.now().AppendFormat(buf.AvailableBuffer(), s.timeFormat))
+		buf.WriteByte(' ')
+	} else if s.stamp {
+		buf.Write(appendHeader(buf.AvailableBuffer(), s.clock(), s.flags))
+	}
+	buf.WriteString(s.prefix)
+	if len(l.name) != 0 {
+		buf.WriteByte('[')
+		buf.WriteString(l.name)
+		buf.WriteString("] ")
+	}
+	writeProcessTokens(buf, s.includePID, s.includeHost)
+
+	if len(callSite) != 0 {
+		buf.WriteString(callSite)
+		buf.WriteByte(' ')
+	}
+
+	// The separator replaces the space which ends the header. Without a timestamp, it is only needed after a prefix, name or call site.
+	if b := buf.Bytes(); len(b) != 0 && b[len(b)-1] == ' ' {
+		buf.Truncate(len(b) - 1)
+	}
+	if buf.Len() != 0 {
+		buf.WriteString(s.sep)
+	} else if s.stamp {
+		buf.WriteString(strings.TrimLeft(s.sep, " "))
+	}
+	if s.color {
+		writeColoredLabel(buf, level, s.label)
+	} else {
+		buf.WriteString(s.label)
+	}
+	buf.WriteString(s.sep)
+	buf.WriteString(trimLineEnd(msg))
+	writeTextFields(buf, fields)
+	endLine(buf, s.ending)
+	writeStackTrace(buf, fields, s.ending)
+
+	out(level, buf.Bytes())
 }
 
-func Debug(msg string, objs ...interface{}) {
-	var level = DEBUG
+// Trace, Debug, Info, Notice, Warn, Error and Critical log their args as fmt.Print does, so that a % in a message is written
+// verbatim. Their f variants, e.g. Infof, log a format string expanded with its args as fmt.Printf does.
+
+func (l *Logger) Info(args ...interface{}) {
+	var level LogLevel = INFO
 	// Select Function based on slice
-	logFunc := logFuncsSlice[level]
-	logFunc(level, msg, objs...)
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, l.sprint(args...))
+	}
 }
 
-func Info(msg string, objs ...interface{}) {
+func (l *Logger) Infof(format string, objs ...interface{}) {
 	var level LogLevel = INFO
 	// Select Function based on slice
-	logFunc := logFuncsSlice[level]
-	logFunc(level, msg, objs...)
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, format, objs...)
+	}
 }
 
-func Warn(msg string, objs ...interface{}) {
+func (l *Logger) Notice(args ...interface{}) {
+	var level LogLevel = NOTICE
+	// Select Function based on slice
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, l.sprint(args...))
+	}
+}
+
+func (l *Logger) Noticef(format string, objs ...interface{}) {
+	var level LogLevel = NOTICE
+	// Select Function based on slice
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, format, objs...)
+	}
+}
+
+func (l *Logger) Warn(args ...interface{}) {
 	var level LogLevel = WARN
 	// Select Function based on slice
-	logFunc := logFuncsSlice[level]
-	logFunc(level, msg, objs...)
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, l.sprint(args...))
+	}
 }
 
-func Error(msg string, objs ...interface{}) {
+func (l *Logger) Warnf(format string, objs ...interface{}) {
+	var level LogLevel = WARN
+	// Select Function based on slice
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, format, objs...)
+	}
+}
+
+func (l *Logger) Error(args ...interface{}) {
+	var level LogLevel = ERROR
+	// Select Function based on slice
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, l.sprint(args...))
+	}
+}
+
+func (l *Logger) Errorf(format string, objs ...interface{}) {
 	var level LogLevel = ERROR
 	// Select Function based on slice
-	logFunc := logFuncsSlice[level]
-	logFunc(level, msg, objs...)
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, format, objs...)
+	}
 }
 
-func Critical(msg string, objs ...interface{}) {
+func (l *Logger) Critical(args ...interface{}) {
 	var level LogLevel = CRITICAL
 	// Select Function based on slice
-	logFunc := logFuncsSlice[level]
-	logFunc(level, msg, objs...)
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, l.sprint(args...))
+	}
+}
+
+func (l *Logger) Criticalf(format string, objs ...interface{}) {
+	var level LogLevel = CRITICAL
+	// Select Function based on slice
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, format, objs...)
+	}
+}
+
+func Info(args ...interface{}) {
+	var level LogLevel = INFO
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, std.sprint(args...))
+	}
+}
+
+func Infof(format string, objs ...interface{}) {
+	var level LogLevel = INFO
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, format, objs...)
+	}
+}
+
+func Notice(args ...interface{}) {
+	var level LogLevel = NOTICE
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, std.sprint(args...))
+	}
+}
+
+func Noticef(format string, objs ...interface{}) {
+	var level LogLevel = NOTICE
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, format, objs...)
+	}
+}
+
+func Warn(args ...interface{}) {
+	var level LogLevel = WARN
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, std.sprint(args...))
+	}
+}
+
+func Warnf(format string, objs ...interface{}) {
+	var level LogLevel = WARN
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, format, objs...)
+	}
+}
+
+func Error(args ...interface{}) {
+	var level LogLevel = ERROR
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, std.sprint(args...))
+	}
+}
+
+func Errorf(format string, objs ...interface{}) {
+	var level LogLevel = ERROR
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, format, objs...)
+	}
+}
+
+func Critical(args ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, std.sprint(args...))
+	}
+}
+
+func Criticalf(format string, objs ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, format, objs...)
+	}
 }
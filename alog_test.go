@@ -1,7 +1,18 @@
 package alog
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 // TestConfigInLocal tests if logging works according to the configuration in alog.conf present in current directory
@@ -23,3 +34,732 @@ func TestLogLevels(t *testing.T) {
 	Critical("This is a CRITICAL message.")
 
 }
+
+func TestNewLoggersAreIndependent(t *testing.T) {
+	var infoBuf, errBuf bytes.Buffer
+	infoLogger := New(&infoBuf, INFO)
+	errLogger := New(&errBuf, ERROR)
+
+	infoLogger.Info("info logger message")
+	errLogger.Info("suppressed message")
+	errLogger.Error("error logger message")
+
+	if got := infoBuf.String(); !strings.Contains(got, "[INFO] - info logger message") {
+		t.Errorf("info logger output = %q, want INFO message", got)
+	}
+	if got := errBuf.String(); strings.Contains(got, "suppressed message") || !strings.Contains(got, "[ERROR] - error logger message") {
+		t.Errorf("error logger output = %q, want only the ERROR message", got)
+	}
+}
+
+func TestSetLogDestinationRepeatedly(t *testing.T) {
+	var first, second bytes.Buffer
+	defer SetLogDestination(os.Stdout)
+
+	SetLogDestination(&first)
+	Critical("first destination")
+	SetLogDestination(&second)
+	Critical("second destination")
+
+	if got := first.String(); !strings.Contains(got, "first destination") || strings.Contains(got, "second destination") {
+		t.Errorf("first destination output = %q", got)
+	}
+	if got := second.String(); !strings.Contains(got, "second destination") {
+		t.Errorf("second destination output = %q, want the message logged after switching", got)
+	}
+}
+
+func TestGetLogDestination(t *testing.T) {
+	var buf bytes.Buffer
+	defer SetLogDestination(os.Stdout)
+
+	SetLogDestination(&buf)
+	if got := GetLogDestination(); got != &buf {
+		t.Errorf("GetLogDestination() = %v, want the buffer set", got)
+	}
+
+	logger := New(os.Stderr, INFO)
+	logger.AddDestination(&buf, TRACE)
+	if got := logger.GetLogDestination(); got != os.Stderr {
+		t.Errorf("GetLogDestination() with an added destination = %v, want os.Stderr", got)
+	}
+}
+
+func TestLogMessageWithPercentIsVerbatim(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+
+	msg := "progress 50% done, WHERE name LIKE 'a%b'"
+	logger.Info(msg)
+
+	if got := buf.String(); !strings.HasSuffix(got, "[INFO] - "+msg+"\n") {
+		t.Errorf("output = %q, want message %q verbatim", got, msg)
+	}
+}
+
+func TestPrintAndPrintfVariants(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	logger.Warn("disk 91% full on ", "sda", 1)
+	logger.Warnf("disk %d%% full on %s", 91, "sda")
+	logger.WithFields(Fields{"id": 7}).Error("100% of ", "retries failed")
+	logger.WithFields(Fields{"id": 7}).Errorf("%d%% of retries failed", 100)
+
+	want := "- [WARN] - disk 91% full on sda1\n" +
+		"- [WARN] - disk 91% full on sda\n" +
+		"- [ERROR] - 100% of retries failed id=7\n" +
+		"- [ERROR] - 100% of retries failed id=7\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// TestVetFlagsFormatInPrint runs go vet on the package with an extra file, laid over it, which passes a format verb to Info
+func TestVetFlagsFormatInPrint(t *testing.T) {
+	if testing.Short() {
+		t.Skip("go vet builds the whole package")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	src := filepath.Join(dir, "misuse.go")
+	if err := os.WriteFile(src, []byte("package alog\n\nfunc misuse(n int) { Info(\"x %d\", n) }\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	overlay, err := json.Marshal(map[string]map[string]string{"Replace": {filepath.Join(wd, "vet_misuse.go"): src}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	overlayFile := filepath.Join(dir, "overlay.json")
+	if err := os.WriteFile(overlayFile, overlay, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(goTool, "vet", "-printf", "-overlay", overlayFile, ".").CombinedOutput()
+	if err == nil || !strings.Contains(string(out), "Info call has possible Printf formatting directive %d") {
+		t.Errorf("go vet error = %v, output = %q, want Info flagged as a print wrapper given a format verb", err, out)
+	}
+}
+
+func TestGetLogLevel(t *testing.T) {
+	defer SetLogLevel(GetLogLevel())
+
+	for _, level := range []LogLevel{TRACE, INFO, CRITICAL} {
+		SetLogLevel(level)
+		if got := GetLogLevel(); got != level {
+			t.Errorf("GetLogLevel() = %v, want %v", got, level)
+		}
+	}
+
+	logger := New(&bytes.Buffer{}, WARN)
+	if got := logger.GetLogLevel(); got != WARN {
+		t.Errorf("logger.GetLogLevel() = %v, want %v", got, WARN)
+	}
+}
+
+func TestIsEnabled(t *testing.T) {
+	logger := New(&bytes.Buffer{}, WARN)
+
+	for level := TRACE; level <= CRITICAL; level++ {
+		if got, want := logger.IsEnabled(level), level >= WARN; got != want {
+			t.Errorf("IsEnabled(%v) = %v, want %v", level, got, want)
+		}
+	}
+	if logger.IsDebugEnabled() || !logger.IsErrorEnabled() {
+		t.Errorf("IsDebugEnabled/IsErrorEnabled do not match level WARN")
+	}
+}
+
+func TestShouldLog(t *testing.T) {
+	for threshold := TRACE; threshold <= OFF; threshold++ {
+		for level := TRACE; level <= CRITICAL; level++ {
+			var buf bytes.Buffer
+			logger := New(&buf, threshold)
+			logger.LogBytes(level, []byte("msg"))
+
+			want := buf.Len() != 0
+			if got := logger.ShouldLog(level); got != want {
+				t.Errorf("ShouldLog(%v) at threshold %v = %v, want %v as written", level, threshold, got, want)
+			}
+		}
+	}
+
+	logger := New(&bytes.Buffer{}, TRACE)
+	logger.Disable()
+	if logger.ShouldLog(CRITICAL) {
+		t.Errorf("ShouldLog(CRITICAL) of a disabled Logger = true, want false")
+	}
+	if logger.ShouldLog(OFF) {
+		t.Errorf("ShouldLog(OFF) = true, want false")
+	}
+}
+
+func expensiveDump() string {
+	return strings.Repeat("x", 1024)
+}
+
+func BenchmarkSuppressedDebug(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	for i := 0; i < b.N; i++ {
+		logger.Debugf("dump: %v", expensiveDump())
+	}
+}
+
+func BenchmarkSuppressedDebugGuarded(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	for i := 0; i < b.N; i++ {
+		if logger.IsDebugEnabled() {
+			logger.Debugf("dump: %v", expensiveDump())
+		}
+	}
+}
+
+// TestConcurrentSetLogLevel is meant to be run with -race
+func TestConcurrentSetLogLevel(t *testing.T) {
+	logger := New(io.Discard, TRACE)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for level := TRACE; level <= CRITICAL; level++ {
+				logger.SetLogLevel(level)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Infof("concurrent message %d", j)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestConcurrentSettersAndLogging is meant to be run with -race
+func TestConcurrentSettersAndLogging(t *testing.T) {
+	logger := New(io.Discard, TRACE)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				logger.SetLogDestination(io.Discard)
+				logger.SetDestinations(io.Discard, io.Discard)
+				logger.SetLogLevel(LogLevel(j) % OFF)
+				logger.SetFormat(Format(j) % (FormatGELF + 1))
+				logger.SetFlags(j)
+				logger.SetPrefix("p")
+				logger.SetLineEnding("\r\n")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Infof("concurrent message %d", j)
+				logger.WithFields(Fields{"j": j}).Error("concurrent error")
+				logger.GetLogLevel()
+				logger.GetFlags()
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// reconfigurer is an arg whose formatting reconfigures the Logger, in the middle of logging the record
+type reconfigurer struct {
+	logger *Logger
+	config Config
+}
+
+func (r reconfigurer) String() string {
+	r.logger.Configure(r.config)
+	return "reconfigured"
+}
+
+func TestRecordSettingsSnapshot(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	stamp := time.Date(2018, 11, 7, 18, 3, 25, 0, time.FixedZone("CET", 3600))
+	logger.SetClock(func() time.Time { return stamp })
+	logger.Configure(Config{Level: INFO, Destination: &buf, Format: FormatJSON, Flags: DefaultFlags, UTC: true})
+
+	logger.Infof("%v", reconfigurer{logger, Config{Level: INFO, Destination: &buf, Format: FormatText, Flags: DefaultFlags}})
+	logger.Info("next")
+
+	want := `{"level":"INFO","msg":"reconfigured","time":"2018-11-07T17:03:25Z"}` + "\n" +
+		"2018/11/07 18:03:25.000000 - [INFO] - next\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q, each record rendered with the settings in force when it was logged", got, want)
+	}
+}
+
+func TestSetFlags(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+
+	if got, want := logger.GetFlags(), log.Ldate|log.Ltime|log.Lmicroseconds; got != want {
+		t.Errorf("default GetFlags() = %d, want %d", got, want)
+	}
+
+	logger.SetFlags(0)
+	logger.Info("no timestamp")
+
+	if got, want := buf.String(), "- [INFO] - no timestamp\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if logger.GetFlags() != 0 {
+		t.Errorf("GetFlags() = %d, want 0", logger.GetFlags())
+	}
+}
+
+func TestSetPrefix(t *testing.T) {
+	var authBuf, dbBuf bytes.Buffer
+	auth := New(&authBuf, INFO)
+	db := New(&dbBuf, INFO)
+	auth.SetPrefix("[auth] ")
+	db.SetFlags(0)
+	db.SetPrefix("[db] ")
+
+	auth.Info("login")
+	db.Warn("slow query")
+
+	if got := authBuf.String(); !regexp.MustCompile(`^\d{4}/\d{2}/\d{2} [\d:.]+ \[auth\] - \[INFO\] - login\n$`).MatchString(got) {
+		t.Errorf("auth output = %q, want the prefix after the timestamp", got)
+	}
+	if got, want := dbBuf.String(), "[db] - [WARN] - slow query\n"; got != want {
+		t.Errorf("db output = %q, want %q", got, want)
+	}
+}
+
+func TestSetSeparator(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	logger.SetSeparator(" | ")
+	logger.Warn("pipe separated")
+	logger.SetSeparator(" ")
+	logger.Info("space separated")
+
+	if got, want := buf.String(), "| [WARN] | pipe separated\n[INFO] space separated\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	logger.SetFlags(DefaultFlags)
+	logger.SetSeparator(" | ")
+	logger.Error("with timestamp")
+	if got := buf.String(); !regexp.MustCompile(`^[\d/]+ [\d:.]+ \| \[ERROR\] \| with timestamp\n$`).MatchString(got) {
+		t.Errorf("output = %q, want \" | \" between the timestamp, level and message", got)
+	}
+
+	buf.Reset()
+	logger.SetSeparator("\t")
+	logger.Error("tab separated")
+	if got := buf.String(); !regexp.MustCompile(`^[\d/]+ [\d:.]+\t\[ERROR\]\ttab separated\n$`).MatchString(got) {
+		t.Errorf("output = %q, want a tab between the timestamp, level and message, and no other delimiter", got)
+	}
+}
+
+func TestSetTimeFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetTimeFormat(time.RFC3339Nano)
+
+	logger.Info("rfc3339")
+
+	stamp, rest, _ := strings.Cut(buf.String(), " ")
+	if _, err := time.Parse(time.RFC3339Nano, stamp); err != nil {
+		t.Errorf("timestamp %q does not parse as RFC3339Nano: %v", stamp, err)
+	}
+	if rest != "- [INFO] - rfc3339\n" {
+		t.Errorf("rest of the record = %q, want the usual layout", rest)
+	}
+
+	buf.Reset()
+	logger.SetTimeFormat("")
+	logger.Info("flags again")
+	if !regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}\.\d{6} - `).MatchString(buf.String()) {
+		t.Errorf("output after SetTimeFormat(\"\") = %q, want the flag based timestamp", buf.String())
+	}
+}
+
+func TestSetTimestampOff(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetTimestamp(false)
+
+	logger.Info("no timestamp")
+	logger.SetPrefix("[auth] ")
+	logger.Warn("after a prefix")
+
+	if got, want := buf.String(), "[INFO] - no timestamp\n[auth] - [WARN] - after a prefix\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	logger.SetFormat(FormatJSON)
+	logger.Info("no time field")
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil || rec["time"] != nil {
+		t.Errorf("JSON output = %q, %v, want no time field", buf.String(), err)
+	}
+
+	buf.Reset()
+	logger.SetTimestamp(true)
+	logger.SetFormat(FormatText)
+	logger.Info("timestamp again")
+	if !regexp.MustCompile(`^\d{4}/\d{2}/\d{2} `).MatchString(buf.String()) {
+		t.Errorf("output after SetTimestamp(true) = %q, want a timestamp", buf.String())
+	}
+}
+
+func TestSetUTC(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(log.Ldate | log.Ltime)
+	logger.SetUTC(true)
+
+	if logger.GetFlags()&log.LUTC == 0 {
+		t.Fatalf("GetFlags() = %d, want log.LUTC set", logger.GetFlags())
+	}
+
+	logger.Info("utc timestamp")
+
+	stamp := strings.SplitN(buf.String(), " - ", 2)[0]
+	got, err := time.Parse("2006/01/02 15:04:05", strings.TrimSpace(stamp))
+	if err != nil {
+		t.Fatalf("unable to parse timestamp %q: %v", stamp, err)
+	}
+	if d := time.Now().UTC().Sub(got); d < -time.Second || d > 2*time.Second {
+		t.Errorf("timestamp %v is %v away from the current UTC time", got, d)
+	}
+
+	logger.SetUTC(false)
+	if logger.GetFlags()&log.LUTC != 0 {
+		t.Errorf("GetFlags() = %d, want log.LUTC cleared", logger.GetFlags())
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	for _, s := range []string{"info", "INFO", "Info"} {
+		if got, err := ParseLevel(s); err != nil || got != INFO {
+			t.Errorf("ParseLevel(%q) = %v, %v, want INFO, nil", s, got, err)
+		}
+	}
+	_, err := ParseLevel("verbose")
+	if _, ok := err.(*InvalidLogLevelError); !ok {
+		t.Errorf("ParseLevel(%q) error = %v, want *InvalidLogLevelError", "verbose", err)
+	}
+	if err == nil || !strings.Contains(err.Error(), "verbose") {
+		t.Errorf("ParseLevel(%q) error = %v, want the bad value in its text", "verbose", err)
+	}
+}
+
+func TestParseLevelAliases(t *testing.T) {
+	for s, want := range map[string]LogLevel{"WARNING": WARN, "warning": WARN, "ERR": ERROR, "Crit": CRITICAL, "CRITICAL": CRITICAL} {
+		if got, err := ParseLevel(s); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v, want %v", s, got, err, want)
+		}
+	}
+	if got := WARN.String(); got != "WARN" {
+		t.Errorf("WARN.String() = %q, want the canonical name", got)
+	}
+}
+
+func TestLogLevelString(t *testing.T) {
+	if got := INFO.String(); got != "INFO" {
+		t.Errorf("INFO.String() = %q, want INFO", got)
+	}
+	if got := LogLevel(42).String(); got != "UNKNOWN(42)" {
+		t.Errorf("LogLevel(42).String() = %q, want UNKNOWN(42)", got)
+	}
+	if err := SetLogLevel(42); !strings.Contains(err.Error(), "UNKNOWN(42)") {
+		t.Errorf("SetLogLevel(42) error = %q, want it to name UNKNOWN(42)", err)
+	}
+}
+
+func TestLogLevelTextRoundTrip(t *testing.T) {
+	type config struct {
+		Level LogLevel `json:"level"`
+	}
+
+	b, err := json.Marshal(config{Level: WARN})
+	if err != nil || string(b) != `{"level":"WARN"}` {
+		t.Fatalf("json.Marshal = %s, %v, want {\"level\":\"WARN\"}", b, err)
+	}
+
+	var c config
+	if err := json.Unmarshal([]byte(`{"level":"error"}`), &c); err != nil || c.Level != ERROR {
+		t.Errorf("json.Unmarshal = %v, %v, want ERROR", c.Level, err)
+	}
+	if err := json.Unmarshal([]byte(`{"level":"loud"}`), &c); err == nil {
+		t.Errorf("json.Unmarshal of an invalid level returned no error")
+	}
+}
+
+func TestApplyEnvLevel(t *testing.T) {
+	defer SetLogLevel(GetLogLevel())
+	SetLogLevel(TRACE)
+
+	t.Setenv("ALOG_LEVEL", "warn")
+	if err := ApplyEnvLevel(); err != nil || GetLogLevel() != WARN {
+		t.Errorf("ApplyEnvLevel() = %v, level %v, want nil, WARN", err, GetLogLevel())
+	}
+
+	t.Setenv("ALOG_LEVEL", "chatty")
+	if err := ApplyEnvLevel(); err == nil || GetLogLevel() != WARN {
+		t.Errorf("ApplyEnvLevel() = %v, level %v, want an error and WARN kept", err, GetLogLevel())
+	}
+}
+
+func TestDisable(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+
+	logger.Disable()
+	logger.Disable()
+	logger.Critical("not even critical")
+	if buf.Len() != 0 {
+		t.Errorf("output while disabled = %q, want nothing", buf.String())
+	}
+	if got := logger.GetLogLevel(); got != OFF {
+		t.Errorf("GetLogLevel() while disabled = %v, want OFF", got)
+	}
+	if logger.IsEnabled(CRITICAL) {
+		t.Errorf("IsEnabled(CRITICAL) while disabled = true")
+	}
+
+	logger.Enable()
+	if got := logger.GetLogLevel(); got != INFO {
+		t.Errorf("GetLogLevel() after Enable = %v, want INFO restored", got)
+	}
+	logger.Info("enabled again")
+	if !strings.Contains(buf.String(), "enabled again") {
+		t.Errorf("output after Enable = %q, want the INFO message", buf.String())
+	}
+
+	if err := logger.SetLogLevel(OFF); err != nil {
+		t.Errorf("SetLogLevel(OFF) error = %v", err)
+	}
+	if level, err := ParseLevel("off"); err != nil || level != OFF {
+		t.Errorf("ParseLevel(%q) = %v, %v, want OFF", "off", level, err)
+	}
+}
+
+func TestNoticeLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, NOTICE)
+	logger.SetFlags(0)
+
+	logger.Info("below notice")
+	logger.Noticef("notice %d", 1)
+	logger.Warn("above notice")
+	if got, want := buf.String(), "- [NOTICE] - notice 1\n- [WARN] - above notice\n"; got != want {
+		t.Errorf("output at NOTICE = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	logger.SetLogLevel(INFO)
+	logger.Notice("written at INFO")
+	logger.SetLogLevel(WARN)
+	logger.Notice("dropped at WARN")
+	if got, want := buf.String(), "- [NOTICE] - written at INFO\n"; got != want {
+		t.Errorf("output at INFO then WARN = %q, want %q", got, want)
+	}
+
+	if !(INFO < NOTICE && NOTICE < WARN) {
+		t.Errorf("NOTICE = %d, want it between INFO (%d) and WARN (%d)", NOTICE, INFO, WARN)
+	}
+	if level, err := ParseLevel("notice"); err != nil || level != NOTICE {
+		t.Errorf("ParseLevel(%q) = %v, %v, want NOTICE", "notice", level, err)
+	}
+}
+
+func TestGoldenTextOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	logger.Info("plain")
+	logger.Warnf("with %d args, %s", 2, "expanded")
+	logger.Error("trailing newline\n")
+	logger.WithFields(Fields{"b": 2, "a": "x"}).Debug("fields")
+
+	want := "- [INFO] - plain\n" +
+		"- [WARN] - with 2 args, expanded\n" +
+		"- [ERROR] - trailing newline\n" +
+		"- [DEBUG] - fields a=x b=2\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+// TestGoldenTextLayout pins the exact spacing of every token of a text record, so that changes to it are intentional
+func TestGoldenTextLayout(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetClock(func() time.Time { return time.Date(2018, time.November, 7, 18, 3, 25, 123456000, time.UTC) })
+	logger.SetFlags(DefaultFlags | log.LUTC)
+
+	logger.Info("msg")
+	logger.SetPrefix("app: ")
+	logger.Named("db").Warn("named")
+	logger.SetPrefix("")
+	logger.SetLevelLabels(map[LogLevel]string{TRACE: "T", DEBUG: "D", INFO: " I ", NOTICE: "N", WARN: "W", ERROR: "E ", CRITICAL: "C"})
+	logger.Info("padded label")
+	logger.Error("  leading spaces kept")
+	logger.SetTimestamp(false)
+	logger.Critical("no timestamp")
+
+	want := "2018/11/07 18:03:25.123456 - [INFO] - msg\n" +
+		"2018/11/07 18:03:25.123456 app: [db] - [WARN] - named\n" +
+		"2018/11/07 18:03:25.123456 - I - padded label\n" +
+		"2018/11/07 18:03:25.123456 - E -   leading spaces kept\n" +
+		"C - no timestamp\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestAppendHeader(t *testing.T) {
+	at := time.Date(2018, time.November, 7, 18, 3, 25, 123456789, time.FixedZone("X", 3600))
+
+	tests := []struct {
+		flags int
+		want  string
+	}{
+		{0, ""},
+		{log.Ldate, "2018/11/07 "},
+		{log.Ltime, "18:03:25 "},
+		{log.Ldate | log.Ltime | log.Lmicroseconds, "2018/11/07 18:03:25.123456 "},
+		{log.Lmicroseconds | log.LUTC, "17:03:25.123456 "},
+	}
+	for _, tt := range tests {
+		if got := string(appendHeader(nil, at, tt.flags)); got != tt.want {
+			t.Errorf("appendHeader(%#x) = %q, want %q", tt.flags, got, tt.want)
+		}
+	}
+}
+
+func TestIndependentOfStdlibLogger(t *testing.T) {
+	var stdlib bytes.Buffer
+	log.SetOutput(&stdlib)
+	log.SetFlags(log.Lshortfile)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.Info("not through log")
+
+	if stdlib.Len() != 0 {
+		t.Errorf("stdlib logger output = %q, want nothing", stdlib.String())
+	}
+	if got, want := buf.String(), "- [INFO] - not through log\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestConcurrentRecordsNotTorn(t *testing.T) {
+	// Neither buffer is safe for concurrent use, so unserialized writes also show up under -race
+	var main, extra bytes.Buffer
+	logger := New(&main, INFO)
+	logger.AddDestination(&extra, TRACE)
+
+	const goroutines, records = 50, 100
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < records; i++ {
+				logger.WithFields(Fields{"g": g}).Infof("record %d from goroutine %d", i, g)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	line := regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}:\d{2}\.\d{6} - \[INFO\] - record (\d+) from goroutine (\d+) g=(\d+)$`)
+	for name, buf := range map[string]*bytes.Buffer{"main": &main, "extra": &extra} {
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != goroutines*records {
+			t.Errorf("%s destination has %d lines, want %d", name, len(lines), goroutines*records)
+		}
+		for _, l := range lines {
+			m := line.FindStringSubmatch(l)
+			if m == nil || m[2] != m[3] {
+				t.Errorf("%s destination has torn line %q", name, l)
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkInfoText(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infof("benchmark message %d", i)
+	}
+}
+
+func BenchmarkInfoTextFields(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	entry := logger.WithFields(Fields{"user": "alice", "attempt": 3})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		entry.Infof("benchmark message %d", i)
+	}
+}
+
+func BenchmarkSuppressedDebugArgs(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Debugf("request %d from %s took %v", i, "alice", time.Millisecond)
+	}
+}
+
+func BenchmarkInfoJSON(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	logger.SetFormat(FormatJSON)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infof("benchmark message %d", i)
+	}
+}
+
+func BenchmarkInfoLogfmt(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	logger.SetFormat(FormatLogfmt)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infof("benchmark message %d", i)
+	}
+}
+
+func BenchmarkInfoParallel(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			logger.Infof("benchmark message %d", i)
+		}
+	})
+}
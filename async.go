@@ -0,0 +1,216 @@
+package alog
+
+import (
+	"io"
+	"sync"
+)
+
+// OverflowPolicy is the type used to specify what happens to a record when the async buffer is full
+type OverflowPolicy uint8
+
+// The overflow policy constants specify how logging behaves when the async buffer is full
+const (
+	// Block makes the logging call wait until there is room in the buffer. This is the default.
+	Block OverflowPolicy = iota
+	// Drop discards the record, so that logging calls never wait on the destination
+	Drop
+)
+
+// asyncRecord is a formatted record queued for the background writer.
+// A record with a non nil flushed channel carries no data; the channel is closed once all the records queued before it are written.
+type asyncRecord struct {
+	level   LogLevel
+	b       []byte
+	flushed chan struct{}
+}
+
+// asyncWriter queues each write onto a channel consumed by a single background goroutine, which performs the actual writes
+type asyncWriter struct {
+	ch chan asyncRecord
+	// mu guards policy and stopped. It is held for reading while a record is queued, so that stop waits for queued records.
+	mu      sync.RWMutex
+	policy  OverflowPolicy
+	stopped bool
+	// outMu guards out, the destination of the background writer
+	outMu sync.Mutex
+	out   io.Writer
+	// fallback takes the records which out fails to write
+	fallback *fallback
+	done     chan struct{}
+}
+
+func newAsyncWriter(out io.Writer, bufferSize int, policy OverflowPolicy, fallback *fallback) *asyncWriter {
+	a := &asyncWriter{
+		ch:       make(chan asyncRecord, bufferSize),
+		policy:   policy,
+		out:      out,
+		fallback: fallback,
+		done:     make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+func (a *asyncWriter) run() {
+	defer close(a.done)
+	for rec := range a.ch {
+		if rec.flushed != nil {
+			close(rec.flushed)
+			continue
+		}
+		a.outMu.Lock()
+		if err := routeRecord(a.out, rec.level, rec.b); err != nil {
+			a.fallback.write(rec.b, err)
+		}
+		a.outMu.Unlock()
+	}
+}
+
+func (a *asyncWriter) setOutput(out io.Writer) {
+	a.outMu.Lock()
+	defer a.outMu.Unlock()
+	a.out = out
+}
+
+// Write queues a copy of p for all the destinations, regardless of their threshold
+func (a *asyncWriter) Write(p []byte) (int, error) {
+	return len(p), a.writeLevel(CRITICAL, p)
+}
+
+// writeLevel queues a copy of the record at level, since the caller may reuse it. Once the writer is stopped, the record is written directly.
+func (a *asyncWriter) writeLevel(level LogLevel, p []byte) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.stopped {
+		a.outMu.Lock()
+		defer a.outMu.Unlock()
+		return routeRecord(a.out, level, p)
+	}
+
+	rec := asyncRecord{level: level, b: append([]byte(nil), p...)}
+	if a.policy == Drop {
+		select {
+		case a.ch <- rec:
+		default:
+		}
+		return nil
+	}
+	a.ch <- rec
+	return nil
+}
+
+// flush waits until all the records queued so far are written
+func (a *asyncWriter) flush() {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.stopped {
+		return
+	}
+	flushed := make(chan struct{})
+	a.ch <- asyncRecord{flushed: flushed}
+	<-flushed
+}
+
+// Sync writes out the queued records and then flushes the destination, if it supports it
+func (a *asyncWriter) Sync() error {
+	a.flush()
+	a.outMu.Lock()
+	defer a.outMu.Unlock()
+	if s, ok := a.out.(syncer); ok {
+		return s.Sync()
+	}
+	return nil
+}
+
+// stop writes out the queued records and terminates the background goroutine
+func (a *asyncWriter) stop() {
+	a.mu.Lock()
+	if a.stopped {
+		a.mu.Unlock()
+		return
+	}
+	a.stopped = true
+	close(a.ch)
+	a.mu.Unlock()
+	<-a.done
+}
+
+func (a *asyncWriter) setPolicy(policy OverflowPolicy) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.policy = policy
+}
+
+// EnableAsync makes the Logger queue formatted records in a buffer of bufferSize records, which a background goroutine writes to the destinations.
+// Logging calls then no longer wait on slow destinations, unless the buffer is full and the overflow policy is Block.
+// Close, DisableAsync and Fatal write out the queued records. Calling EnableAsync again replaces the buffer.
+// A bufferSize of 0, or less, makes each logging call wait until the background goroutine takes its record.
+func (l *Logger) EnableAsync(bufferSize int) {
+	if bufferSize < 0 {
+		bufferSize = 0
+	}
+	l.DisableAsync()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.async = newAsyncWriter(l.output(), bufferSize, l.overflow, &l.fallback)
+	l.updateOutput()
+}
+
+// DisableAsync writes out the queued records and switches the Logger back to synchronous writes
+func (l *Logger) DisableAsync() {
+	l.mu.Lock()
+	a := l.async
+	l.async = nil
+	l.updateOutput()
+	l.mu.Unlock()
+
+	if a != nil {
+		a.stop()
+	}
+}
+
+// SetOverflowPolicy sets what happens to records logged while the async buffer is full. The default is Block.
+func (l *Logger) SetOverflowPolicy(policy OverflowPolicy) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.overflow = policy
+	if l.async != nil {
+		l.async.setPolicy(policy)
+	}
+}
+
+// Flush writes out the records queued by the async writer, without stopping it.
+// It is a no-op which returns nil when the Logger is not buffered.
+func (l *Logger) Flush() error {
+	l.mu.RLock()
+	a := l.async
+	l.mu.RUnlock()
+
+	if a != nil {
+		a.flush()
+	}
+	return nil
+}
+
+// Flush writes out the records queued by the default Logger, e.g. before a crash handler runs
+func Flush() error {
+	return std.Flush()
+}
+
+// EnableAsync makes the default Logger write records from a background goroutine, see (*Logger).EnableAsync
+func EnableAsync(bufferSize int) {
+	std.EnableAsync(bufferSize)
+}
+
+// DisableAsync switches the default Logger back to synchronous writes
+func DisableAsync() {
+	std.DisableAsync()
+}
+
+// SetOverflowPolicy sets what happens to records logged by the default Logger while the async buffer is full
+func SetOverflowPolicy(policy OverflowPolicy) {
+	std.SetOverflowPolicy(policy)
+}
@@ -0,0 +1,133 @@
+package alog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockedBuffer is a bytes.Buffer which may be written by the background writer while the test reads it
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestAsyncDrainsOnDisable(t *testing.T) {
+	var buf lockedBuffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.EnableAsync(16)
+
+	for i := 0; i < 100; i++ {
+		logger.Infof("record %d", i)
+	}
+	logger.DisableAsync()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 100 {
+		t.Fatalf("got %d lines, want 100", len(lines))
+	}
+	for i, line := range lines {
+		if want := fmt.Sprintf("- [INFO] - record %d", i); line != want {
+			t.Fatalf("line %d = %q, want %q", i, line, want)
+		}
+	}
+
+	logger.Info("synchronous again")
+	if !strings.HasSuffix(buf.String(), "synchronous again\n") {
+		t.Errorf("record logged after DisableAsync was not written immediately")
+	}
+}
+
+// blockingWriter blocks every write until release is closed
+type blockingWriter struct {
+	release chan struct{}
+	lockedBuffer
+}
+
+func (b *blockingWriter) Write(p []byte) (int, error) {
+	<-b.release
+	return b.lockedBuffer.Write(p)
+}
+
+func TestAsyncNegativeBufferSize(t *testing.T) {
+	var buf lockedBuffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.EnableAsync(-1)
+
+	logger.Info("unbuffered")
+	logger.DisableAsync()
+
+	if got, want := buf.String(), "- [INFO] - unbuffered\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestAsyncDropPolicy(t *testing.T) {
+	w := &blockingWriter{release: make(chan struct{})}
+	logger := New(w, INFO)
+	logger.SetOverflowPolicy(Drop)
+	logger.EnableAsync(1)
+
+	// None of these calls may block, even though the destination does not accept any write
+	for i := 0; i < 10; i++ {
+		logger.Infof("record %d", i)
+	}
+	close(w.release)
+	logger.Close()
+
+	if n := strings.Count(w.String(), "\n"); n == 0 || n >= 10 {
+		t.Errorf("got %d lines, want some but not all of the 10 records dropped", n)
+	}
+}
+
+func BenchmarkInfoSync(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	for i := 0; i < b.N; i++ {
+		logger.Infof("benchmark message %d", i)
+	}
+}
+
+func BenchmarkInfoAsync(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	logger.EnableAsync(1024)
+	defer logger.DisableAsync()
+	for i := 0; i < b.N; i++ {
+		logger.Infof("benchmark message %d", i)
+	}
+}
+
+func TestFlush(t *testing.T) {
+	var buf lockedBuffer
+	logger := New(&buf, INFO)
+	if err := logger.Flush(); err != nil {
+		t.Errorf("Flush() of an unbuffered Logger = %v, want nil", err)
+	}
+
+	logger.EnableAsync(16)
+	defer logger.DisableAsync()
+	logger.Info("flushed record")
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "flushed record") {
+		t.Errorf("output after Flush = %q, want the queued record", buf.String())
+	}
+}
@@ -0,0 +1,58 @@
+package alog
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// callerDepth is the number of stack frames between caller and the user's call site :
+// caller <- logMsg <- Trace/Debug/... <- user code.
+// Every exported logging function must therefore invoke its log function value directly.
+const callerDepth = 3
+
+// caller returns the "file:line" of the user's call site.
+// The frames of the io.Writer adapters, and of fmt, io and log writing to them, are skipped, so that a line written to a
+// PrefixParsingWriter, or through a *log.Logger to a LevelWriter, reports the code which wrote it.
+// It returns "" for the records of the HTTPHandler middleware, which have no call site in the user's code.
+func caller() string {
+	var pcs [16]uintptr
+	// The first frame is the one which invoked the log function value, e.g. Info
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(callerDepth, pcs[:])])
+	if entry, _ := frames.Next(); entry.Function[strings.LastIndexByte(entry.Function, '/')+1:] == "alog.(*Logger).HTTPHandler.func1" {
+		return ""
+	}
+	for {
+		frame, more := frames.Next()
+		if !adapterFrame(frame.Function) || !more {
+			if frame.PC == 0 {
+				return "???:0"
+			}
+			return fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+		}
+	}
+}
+
+// adapterFrame reports whether function, a fully qualified function name, sits between the user's code and the log function
+func adapterFrame(function string) bool {
+	for _, pkg := range []string{"fmt.", "io.", "log."} {
+		if strings.HasPrefix(function, pkg) {
+			return true
+		}
+	}
+	name := function[strings.LastIndexByte(function, '/')+1:]
+	return strings.HasPrefix(name, "alog.(*prefixWriter).") || strings.HasPrefix(name, "alog.(*levelWriter).")
+}
+
+// SetCallerInfo turns on or off the reporting of the source file and line number of the call site in each record of the Logger
+func (l *Logger) SetCallerInfo(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.callerInfo = enabled
+}
+
+// SetCallerInfo turns on or off the caller information in the records of the default Logger
+func SetCallerInfo(enabled bool) {
+	std.SetCallerInfo(enabled)
+}
@@ -0,0 +1,29 @@
+package alog
+
+import (
+	"bytes"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCallerInfo(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetCallerInfo(true)
+
+	_, _, line, _ := runtime.Caller(0)
+	logger.Info("where am I")
+	logger.WithFields(Fields{"k": "v"}).Info("and now")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for i, want := range []string{
+		fmt.Sprintf("caller_test.go:%d - [INFO] - where am I", line+1),
+		fmt.Sprintf("caller_test.go:%d - [INFO] - and now", line+2),
+	} {
+		if !strings.Contains(lines[i], want) {
+			t.Errorf("line %d = %q, want it to contain %q", i, lines[i], want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package alog
+
+import "time"
+
+// SetClock makes the Logger take the time of its records from clock instead of time.Now, which allows tests to
+// check timestamps and to simulate the day boundaries of daily rotated log files opened by the Logger.
+// SetClock(nil) goes back to time.Now. The windows of SetDedup still run on the real time.
+func (l *Logger) SetClock(clock func() time.Time) {
+	if clock == nil {
+		clock = time.Now
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.clock = clock
+	l.applyClock()
+}
+
+// SetClock makes the default Logger take the time of its records from clock
+func SetClock(clock func() time.Time) {
+	std.SetClock(clock)
+}
+
+// applyClock makes the rotating log file of the Logger, if any, follow its clock. l.mu must be held.
+func (l *Logger) applyClock() {
+	if r, ok := l.file.(*rotatingFile); ok {
+		r.setNow(l.clock)
+	}
+}
+
+// setNow replaces the source of the current time of the rotating file
+func (r *rotatingFile) setNow(now func() time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.now = now
+}
@@ -0,0 +1,63 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetClock(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	at := time.Date(2018, time.November, 7, 18, 3, 25, 123456000, time.Local)
+	logger.SetClock(func() time.Time { return at })
+
+	logger.Info("fixed time")
+	logger.SetFormat(FormatJSON)
+	logger.Info("fixed time")
+
+	text, js, _ := strings.Cut(buf.String(), "\n")
+	if want := "2018/11/07 18:03:25.123456 - [INFO] - fixed time"; text != want {
+		t.Errorf("text output = %q, want %q", text, want)
+	}
+	var rec map[string]interface{}
+	if err := json.Unmarshal([]byte(js), &rec); err != nil || rec["time"] != at.Format(time.RFC3339Nano) {
+		t.Errorf("JSON output = %q, %v, want time %s", js, err, at.Format(time.RFC3339Nano))
+	}
+
+	buf.Reset()
+	logger.SetClock(nil)
+	logger.Info("real time")
+	if strings.Contains(buf.String(), "2018") {
+		t.Errorf("output after SetClock(nil) = %q, want the current time", buf.String())
+	}
+}
+
+func TestSetClockRotatesFile(t *testing.T) {
+	dir := t.TempDir()
+	logger, err := NewFileWithOptions(filepath.Join(dir, "app.log"), INFO, FileOptions{Rotation: RotateDaily})
+	if err != nil {
+		t.Fatalf("NewFileWithOptions() error = %v", err)
+	}
+	defer logger.Close()
+
+	now := time.Date(2030, 1, 1, 23, 59, 59, 0, time.Local)
+	logger.SetClock(func() time.Time { return now })
+	logger.Info("new year's day")
+	now = now.Add(2 * time.Second)
+	logger.Info("the day after")
+	logger.Close()
+
+	for fileName, want := range map[string]string{
+		"app-2030-01-01.log": "new year's day",
+		"app-2030-01-02.log": "the day after",
+	} {
+		if b, err := os.ReadFile(filepath.Join(dir, fileName)); err != nil || !strings.Contains(string(b), want) {
+			t.Errorf("%s contents = %q, %v, want %q", fileName, b, err, want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package alog
+
+import (
+	"bytes"
+	"os"
+	"strings"
+)
+
+// ColorMode is the type used to specify whether level labels are colored with ANSI escape codes
+type ColorMode uint8
+
+// The color mode constants specify when level labels are colored
+const (
+	// ColorNever writes plain labels. This is the default.
+	ColorNever ColorMode = iota
+	// ColorAuto colors labels when the destination is a terminal, unless overridden by the FORCE_COLOR, CLICOLOR_FORCE
+	// and NO_COLOR environment variables, see SetColor
+	ColorAuto
+	// ColorAlways colors labels regardless of the destination
+	ColorAlways
+)
+
+const colorReset = "\x1b[0m"
+
+var logLevelColorMap = map[LogLevel]string{
+	TRACE:    "\x1b[90m",
+	DEBUG:    "\x1b[36m",
+	INFO:     "\x1b[32m",
+	NOTICE:   "\x1b[34m",
+	WARN:     "\x1b[33m",
+	ERROR:    "\x1b[31m",
+	CRITICAL: "\x1b[1;31m",
+}
+
+// writeColoredLabel writes the level label in the color of its level
+func writeColoredLabel(sb *bytes.Buffer, level LogLevel, label string) {
+	sb.WriteString(logLevelColorMap[level])
+	sb.WriteString(label)
+	sb.WriteString(colorReset)
+}
+
+// isTerminal reports whether w is an *os.File connected to a terminal
+func isTerminal(w interface{}) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// envColor returns whether the environment forces colors on, with FORCE_COLOR or CLICOLOR_FORCE, or off, with NO_COLOR,
+// and whether it says anything at all. Forcing takes precedence, and a value of 0 or false does not force colors on.
+func envColor() (on bool, set bool) {
+	for _, name := range []string{"FORCE_COLOR", "CLICOLOR_FORCE"} {
+		if s := os.Getenv(name); len(s) != 0 && s != "0" && !strings.EqualFold(s, "false") {
+			return true, true
+		}
+	}
+	if len(os.Getenv("NO_COLOR")) != 0 {
+		return false, true
+	}
+	return false, false
+}
+
+// useColor works out whether level labels should be colored for the current color mode and destination. l.mu must be held.
+func (l *Logger) useColor() bool {
+	switch l.colorMode {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		if on, set := envColor(); set {
+			return on
+		}
+		return isTerminal(l.out)
+	default:
+		return false
+	}
+}
+
+// SetColor sets when the level labels in the text records of the Logger are colored, e.g. red for ERROR and yellow for WARN.
+// ColorAlways and ColorNever take precedence over the environment. With ColorAuto, a non empty FORCE_COLOR or CLICOLOR_FORCE
+// turns colors on, otherwise a non empty NO_COLOR turns them off, otherwise they are on if the destination is a terminal.
+// The environment is consulted when the mode or the destination is set.
+func (l *Logger) SetColor(mode ColorMode) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.colorMode = mode
+	l.colorOn = l.useColor()
+}
+
+// SetColor sets when the level labels in the text records of the default Logger are colored
+func SetColor(mode ColorMode) {
+	std.SetColor(mode)
+}
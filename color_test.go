@@ -0,0 +1,70 @@
+package alog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestColorAlways(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetColor(ColorAlways)
+
+	logger.Warn("colored")
+
+	if got, want := buf.String(), "- \x1b[33m[WARN]\x1b[0m - colored\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestColorAutoNotATerminal(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetColor(ColorAuto)
+
+	logger.Error("plain")
+
+	if got, want := buf.String(), "- [ERROR] - plain\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestColorEnvironment(t *testing.T) {
+	tests := []struct {
+		name                     string
+		force, cliForce, noColor string
+		mode                     ColorMode
+		want                     bool
+	}{
+		{name: "auto without env", mode: ColorAuto, want: false},
+		{name: "FORCE_COLOR", force: "1", mode: ColorAuto, want: true},
+		{name: "CLICOLOR_FORCE", cliForce: "1", mode: ColorAuto, want: true},
+		{name: "FORCE_COLOR=0", force: "0", mode: ColorAuto, want: false},
+		{name: "FORCE_COLOR over NO_COLOR", force: "true", noColor: "1", mode: ColorAuto, want: true},
+		{name: "NO_COLOR", noColor: "1", mode: ColorAuto, want: false},
+		{name: "ColorNever over FORCE_COLOR", force: "1", mode: ColorNever, want: false},
+		{name: "ColorAlways over NO_COLOR", noColor: "1", mode: ColorAlways, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("FORCE_COLOR", tt.force)
+			t.Setenv("CLICOLOR_FORCE", tt.cliForce)
+			t.Setenv("NO_COLOR", tt.noColor)
+
+			var buf bytes.Buffer
+			logger := New(&buf, INFO)
+			logger.SetColor(tt.mode)
+			logger.Warn("msg")
+			if got := bytes.Contains(buf.Bytes(), []byte("\x1b[")); got != tt.want {
+				t.Errorf("colored = %v, want %v, output %q", got, tt.want, buf.String())
+			}
+		})
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if on, set := envColor(); on || !set {
+		t.Errorf("envColor() with NO_COLOR = %v, %v, want false, true", on, set)
+	}
+}
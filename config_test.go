@@ -0,0 +1,470 @@
+package alog
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// chdirTemp changes into a new temporary directory, so that its alog.conf is the one picked up, for the duration of the test
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+// restoreDefaultLogger restores the level and destination of the default Logger at the end of the test.
+// The destination is marked as not set with SetLogDestination, at the start and the end, so that alog.conf can set it.
+func restoreDefaultLogger(t *testing.T) {
+	level := GetLogLevel()
+	clearDestinationSet()
+	t.Cleanup(func() {
+		Close()
+		SetLogDestination(os.Stdout)
+		SetLogLevel(level)
+		clearDestinationSet()
+	})
+}
+
+func clearDestinationSet() {
+	std.mu.Lock()
+	defer std.mu.Unlock()
+	std.destinationSet = false
+}
+
+func writeConfig(t *testing.T, dir, contents string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, "alog.conf"), []byte(contents), 0666); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReloadConfig(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+
+	writeConfig(t, dir, `alog {
+    fileName = "app.log"
+    logLevel = "DEBUG"
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	if got := GetLogLevel(); got != DEBUG {
+		t.Errorf("level after first reload = %v, want DEBUG", got)
+	}
+
+	writeConfig(t, dir, `alog {
+    fileName = "app.log"
+    logLevel = "ERROR"
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	if got := GetLogLevel(); got != ERROR {
+		t.Errorf("level after second reload = %v, want ERROR", got)
+	}
+
+	Error("reloaded")
+	if b, err := os.ReadFile(filepath.Join(dir, "app.log")); err != nil || len(b) == 0 {
+		t.Errorf("app.log contents = %q, %v, want the logged line", b, err)
+	}
+}
+
+func TestReloadConfigKeepsTruncatedFile(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "app.log"), []byte("previous run\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig(t, dir, `alog {
+    fileName = "app.log"
+    logLevel = "INFO"
+    append = "false"
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	Info("before reload")
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	Info("after reload")
+
+	b, err := os.ReadFile(filepath.Join(dir, "app.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); strings.Contains(got, "previous run") || !strings.Contains(got, "before reload") || !strings.Contains(got, "after reload") {
+		t.Errorf("app.log contents = %q, want the records since startup only", got)
+	}
+}
+
+func TestConfigFileNameExpandsEnv(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	logDir := filepath.Join(dir, "var", "log")
+	t.Setenv("LOG_DIR", logDir)
+
+	writeConfig(t, dir, `alog {
+    fileName = "${LOG_DIR}/app.log"
+    logLevel = "INFO"
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	Info("expanded")
+
+	if b, err := os.ReadFile(filepath.Join(logDir, "app.log")); err != nil || len(b) == 0 {
+		t.Errorf("contents of $LOG_DIR/app.log = %q, %v, want the logged line", b, err)
+	}
+}
+
+func TestConfigAppendFalseTruncates(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	fileName := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(fileName, []byte("previous run\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	writeConfig(t, dir, `alog {
+    fileName = "app.log"
+    logLevel = "INFO"
+    append = "false"
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	Info("this run")
+
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(b); strings.Contains(got, "previous run") || !strings.Contains(got, "this run") {
+		t.Errorf("app.log contents = %q, want only the line from this run", got)
+	}
+}
+
+func TestFileExistsStatError(t *testing.T) {
+	notADir := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(notADir, nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stat fails with ENOTDIR, which is not a not-exist error
+	path := filepath.Join(notADir, "alog.conf")
+	if _, err := os.Stat(path); err == nil || os.IsNotExist(err) {
+		t.Skipf("os.Stat(%q) error = %v, want a non not-exist error", path, err)
+	}
+	if fileExists(path) {
+		t.Errorf("fileExists(%q) = true, want false", path)
+	}
+	if fileExists(filepath.Dir(notADir)) {
+		t.Errorf("fileExists of a directory = true, want false")
+	}
+}
+
+func TestReloadConfigMalformed(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	SetLogLevel(WARN)
+
+	writeConfig(t, dir, `alog {
+    logLevel = "DEBUG"
+`)
+	if err := ReloadConfig(); err == nil {
+		t.Errorf("ReloadConfig() of malformed HOCON returned no error")
+	}
+	if got := GetLogLevel(); got != WARN {
+		t.Errorf("level after a failed reload = %v, want WARN kept", got)
+	}
+}
+
+func TestConfigInvalidLevelUsesDefault(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	SetLogLevel(ERROR)
+
+	writeConfig(t, dir, `alog {
+    logLevel = "VERBOSE"
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	if got := GetLogLevel(); got != defaultLogLevel {
+		t.Errorf("level after an invalid logLevel = %v, want the default %v", got, defaultLogLevel)
+	}
+}
+
+func TestAutoInitDisabled(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	SetLogLevel(TRACE)
+	t.Setenv("ALOG_AUTOINIT", "0")
+	t.Setenv("ALOG_LEVEL", "ERROR")
+
+	writeConfig(t, dir, `alog {
+    fileName = "app.log"
+    logLevel = "WARN"
+}`)
+	if err := autoInit(); err != nil {
+		t.Fatalf("autoInit() error = %v", err)
+	}
+	if got := GetLogLevel(); got != TRACE {
+		t.Errorf("level with ALOG_AUTOINIT=0 = %v, want TRACE unchanged", got)
+	}
+	if got := GetLogDestination(); got != os.Stdout {
+		t.Errorf("destination with ALOG_AUTOINIT=0 = %v, want os.Stdout", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app.log")); err == nil {
+		t.Errorf("app.log was created with ALOG_AUTOINIT=0")
+	}
+
+	t.Setenv("ALOG_AUTOINIT", "1")
+	if err := autoInit(); err != nil {
+		t.Fatalf("autoInit() error = %v", err)
+	}
+	if got := GetLogLevel(); got != ERROR {
+		t.Errorf("level with ALOG_AUTOINIT=1 = %v, want ERROR from ALOG_LEVEL", got)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	writeConfig(t, dir, `alog {
+    logLevel = "CRITICAL"
+}`)
+
+	fileName := filepath.Join(dir, "logs", "app.log")
+	conf := fmt.Sprintf(`alog {
+    fileName = %q
+    logLevel = "WARN"
+    utc = "true"
+    append = "false"
+}`, fileName)
+	if err := LoadConfig(strings.NewReader(conf)); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if got := GetLogLevel(); got != WARN {
+		t.Errorf("level after LoadConfig() = %v, want WARN from the reader, not alog.conf", got)
+	}
+	if got := GetFlags(); got&log.LUTC == 0 {
+		t.Errorf("flags after LoadConfig() = %d, want log.LUTC set", got)
+	}
+	Warn("loaded from a reader")
+	Close()
+
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), "[WARN] - loaded from a reader") {
+		t.Errorf("log file = %q, want the WARN record", b)
+	}
+
+	if err := LoadConfigFormat(strings.NewReader(`{"alog": {"logLevel": "ERROR"}}`), ConfigJSON); err != nil {
+		t.Fatalf("LoadConfigFormat() error = %v", err)
+	}
+	if got := GetLogLevel(); got != ERROR {
+		t.Errorf("level after LoadConfigFormat() = %v, want ERROR", got)
+	}
+
+	if err := LoadConfig(strings.NewReader("alog {")); err == nil {
+		t.Errorf("LoadConfig() of malformed HOCON returned no error")
+	}
+	if got := GetLogLevel(); got != ERROR {
+		t.Errorf("level after a failed LoadConfig() = %v, want ERROR kept", got)
+	}
+}
+
+func TestLoggerFromConfigYAMLAndJSON(t *testing.T) {
+	configs := map[string]string{
+		"alog.yaml": `alog:
+  logLevel: ERROR
+  http:
+    logLevel: DEBUG
+    fileName: ""
+  utc: "false"
+`,
+		"alog.json": `{"alog": {"logLevel": "ERROR", "http": {"logLevel": "DEBUG"}}}`,
+	}
+	for name, contents := range configs {
+		t.Run(name, func(t *testing.T) {
+			dir := chdirTemp(t)
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(contents), 0666); err != nil {
+				t.Fatal(err)
+			}
+
+			logger, err := LoggerFromConfig("http")
+			if err != nil {
+				t.Fatalf("LoggerFromConfig(%q) error = %v", "http", err)
+			}
+			if got := logger.GetLogLevel(); got != DEBUG {
+				t.Errorf("level of the http Logger = %v, want DEBUG", got)
+			}
+
+			f, err := os.Open(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+			section, err := parseConfigSection(f, configFormatOf(name), "")
+			if err != nil {
+				t.Fatalf("parseConfigSection() error = %v", err)
+			}
+			if section.LogLevel != "ERROR" {
+				t.Errorf("logLevel of the alog block = %q, want ERROR", section.LogLevel)
+			}
+			if name == "alog.yaml" && section.UTC != "false" {
+				t.Errorf("utc of the alog block, after the http section = %q, want false", section.UTC)
+			}
+		})
+	}
+}
+
+func TestSetLogDestinationOverridesConfig(t *testing.T) {
+	for _, destinationFirst := range []bool{false, true} {
+		t.Run(fmt.Sprintf("destinationFirst=%v", destinationFirst), func(t *testing.T) {
+			dir := chdirTemp(t)
+			restoreDefaultLogger(t)
+			writeConfig(t, dir, `alog {
+    fileName = "app.log"
+    logLevel = "DEBUG"
+}`)
+
+			var buf bytes.Buffer
+			if destinationFirst {
+				SetLogDestination(&buf)
+			}
+			if err := ReloadConfig(); err != nil {
+				t.Fatalf("ReloadConfig() error = %v", err)
+			}
+			if !destinationFirst {
+				SetLogDestination(&buf)
+			}
+			Info("kept on the destination")
+
+			if got := GetLogDestination(); got != &buf {
+				t.Errorf("destination = %v, want the one set with SetLogDestination", got)
+			}
+			got := buf.String()
+			if !strings.Contains(got, "[DEBUG] - alog: log file app.log overridden by the destination set with SetLogDestination") {
+				t.Errorf("output = %q, want a DEBUG note of the override", got)
+			}
+			if !strings.Contains(got, "[INFO] - kept on the destination") {
+				t.Errorf("output = %q, want the INFO record", got)
+			}
+			if b, _ := os.ReadFile(filepath.Join(dir, "app.log")); strings.Contains(string(b), "kept on the destination") {
+				t.Errorf("app.log = %q, want no records", b)
+			}
+		})
+	}
+}
+
+func TestConfigDirectoryIgnored(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	SetLogLevel(CRITICAL)
+	if err := os.Mkdir(filepath.Join(dir, "alog.conf"), 0777); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() with a directory named alog.conf error = %v", err)
+	}
+	if got := GetLogLevel(); got != defaultLogLevel {
+		t.Errorf("level with a directory named alog.conf = %v, want the default %v", got, defaultLogLevel)
+	}
+	if got := GetLogDestination(); got != os.Stdout {
+		t.Errorf("destination with a directory named alog.conf = %v, want os.Stdout", got)
+	}
+}
+
+func TestYAMLAndJSONConfig(t *testing.T) {
+	configs := map[string]string{
+		"alog.conf": `alog {
+    fileName = "app.log"
+    logLevel = "WARN"
+    utc = "true"
+    fileMode = "0640"
+}`,
+		"alog.yaml": `# same settings as alog.conf
+alog:
+  fileName: "app.log"
+  logLevel: WARN   # only WARN and above
+  utc: true
+  fileMode: '0640'
+`,
+		"alog.json": `{"alog": {"fileName": "app.log", "logLevel": "WARN", "utc": true, "fileMode": "0640"}}`,
+	}
+
+	want := configSection{FileName: "app.log", LogLevel: "WARN", UTC: "true", FileMode: "0640"}
+	for name, contents := range configs {
+		conf, err := parseConfig(strings.NewReader(contents), configFormatOf(name))
+		if err != nil {
+			t.Errorf("parseConfig of %s error = %v", name, err)
+			continue
+		}
+		if conf.Alog != want {
+			t.Errorf("parseConfig of %s = %+v, want %+v", name, conf.Alog, want)
+		}
+	}
+}
+
+func TestReloadYAMLConfig(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+
+	if err := os.WriteFile(filepath.Join(dir, "alog.yml"), []byte("alog:\n  fileName: app.log\n  logLevel: error\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	if got := GetLogLevel(); got != ERROR {
+		t.Errorf("level = %v, want ERROR", got)
+	}
+	Error("from yaml config")
+	if b, err := os.ReadFile(filepath.Join(dir, "app.log")); err != nil || !strings.Contains(string(b), "from yaml config") {
+		t.Errorf("app.log contents = %q, %v, want the logged line", b, err)
+	}
+}
+
+func TestMalformedYAMLConfig(t *testing.T) {
+	for _, contents := range []string{"alog: oops\n", "  logLevel: INFO\n", "alog:\n  logLevel \"INFO\"\n"} {
+		if _, err := parseConfig(strings.NewReader(contents), ConfigYAML); err == nil {
+			t.Errorf("parseConfig(%q) returned no error", contents)
+		}
+	}
+}
+
+func TestConfigLevelAlias(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+
+	writeConfig(t, dir, `alog {
+    logLevel = "WARNING"
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	if got := GetLogLevel(); got != WARN {
+		t.Errorf("level = %v, want WARN", got)
+	}
+}
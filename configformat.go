@@ -0,0 +1,291 @@
+package alog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/en-vee/aconf"
+)
+
+// ConfigFormat is the syntax of a logger config file
+type ConfigFormat uint8
+
+// The config format constants specify the syntaxes a logger config file can be written in
+const (
+	// ConfigHOCON is the syntax of alog.conf. This is the default.
+	ConfigHOCON ConfigFormat = iota
+	// ConfigYAML accepts the alog block as a YAML mapping of keys to scalar values
+	ConfigYAML
+	// ConfigJSON accepts the alog block as a JSON object, e.g. {"alog": {"logLevel": "INFO"}}
+	ConfigJSON
+)
+
+// loggerConfigFileNames are the names of the logger config file which are looked for, in order of preference
+var loggerConfigFileNames = []string{loggerConfigFileName, "alog.hocon", "alog.yaml", "alog.yml", "alog.json"}
+
+// configFormatOf returns the syntax of the config file by its extension: .yaml and .yml are YAML, .json is JSON
+// and everything else, including .conf and .hocon, is HOCON
+func configFormatOf(fileName string) ConfigFormat {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".yaml", ".yml":
+		return ConfigYAML
+	case ".json":
+		return ConfigJSON
+	default:
+		return ConfigHOCON
+	}
+}
+
+// LoadConfig parses a logger config in HOCON, the syntax of alog.conf, from r and applies it to the default Logger,
+// followed by the ALOG_LEVEL override, as is done at startup. The config file lookup is bypassed, which allows the
+// config to be embedded in the program or fetched from elsewhere. If r cannot be parsed, the current configuration is kept.
+func LoadConfig(r io.Reader) error {
+	return LoadConfigFormat(r, ConfigHOCON)
+}
+
+// LoadConfigFormat parses a logger config in the given syntax from r and applies it to the default Logger, see LoadConfig
+func LoadConfigFormat(r io.Reader, format ConfigFormat) error {
+	alogConfig, err := parseConfig(r, format)
+	if err != nil {
+		return err
+	}
+	applyConfig(&alogConfig.Alog)
+	ApplyEnvLevel()
+	return nil
+}
+
+// parseConfig parses a logger config file in the given syntax
+func parseConfig(r io.Reader, format ConfigFormat) (*configFile, error) {
+	section, err := parseConfigSection(r, format, "")
+	if err != nil {
+		return nil, err
+	}
+	return &configFile{Alog: *section}, nil
+}
+
+// parseConfigSection parses the alog block of a logger config file in the given syntax or, if name is not empty,
+// the section of that name nested inside it. A section which is absent is returned empty.
+func parseConfigSection(r io.Reader, format ConfigFormat, name string) (*configSection, error) {
+	var sections map[string]map[string]string
+	var err error
+
+	switch format {
+	case ConfigYAML:
+		sections, err = parseYAMLSections(r)
+	case ConfigJSON:
+		sections, err = parseJSONSections(r)
+	default:
+		return parseHOCONSection(r, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	path := "alog"
+	if len(name) != 0 {
+		path += "." + name
+	}
+	section := &configSection{}
+	for key, value := range sections[path] {
+		section.set(key, value)
+	}
+	return section, nil
+}
+
+// parseHOCONSection parses the alog block of a HOCON config or, if name is not empty, the section of that name inside it.
+// Since the HOCON parser decodes by struct tags, a named section is decoded through a struct type built for its name.
+func parseHOCONSection(r io.Reader, name string) (*configSection, error) {
+	configParser := &aconf.HoconParser{}
+	if len(name) == 0 {
+		alogConfig := &configFile{}
+		if err := configParser.Parse(r, alogConfig); err != nil {
+			return nil, err
+		}
+		return &alogConfig.Alog, nil
+	}
+
+	sectionType := reflect.StructOf([]reflect.StructField{
+		{Name: "Section", Type: reflect.TypeOf(configSection{}), Tag: reflect.StructTag(fmt.Sprintf("hocon:%q", name))},
+	})
+	fileType := reflect.StructOf([]reflect.StructField{
+		{Name: "Alog", Type: sectionType, Tag: `hocon:"alog"`},
+	})
+	file := reflect.New(fileType)
+	if err := configParser.Parse(r, file.Interface()); err != nil {
+		return nil, err
+	}
+	section := file.Elem().Field(0).Field(0).Interface().(configSection)
+	return &section, nil
+}
+
+// LoggerFromConfig creates a Logger configured by the section of the given name nested in the alog block of the logger
+// config file, which is looked up as at startup. For example, LoggerFromConfig("http") reads
+//
+//	alog {
+//	    logLevel = "INFO"
+//	    http {
+//	        fileName = "/var/log/app/http.log"
+//	        logLevel = "DEBUG"
+//	    }
+//	}
+//
+// The section takes the same keys as the alog block, which keeps configuring the default Logger. A section which is
+// absent, or has no fileName, gives a Logger writing to stdout, and invalid values are reported on stderr and replaced by
+// their defaults. An error is returned if the config file cannot be parsed or the log file cannot be opened.
+func LoggerFromConfig(name string) (*Logger, error) {
+	section := &configSection{}
+	if fileName := configFileName(); fileExists(fileName) {
+		reader, err := os.Open(fileName)
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		if section, err = parseConfigSection(reader, configFormatOf(fileName), name); err != nil {
+			return nil, err
+		}
+	}
+
+	c := section.config()
+	c.Flags = DefaultFlags
+	l := New(os.Stdout, c.Level)
+	if err := l.Configure(c); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// set assigns value to the field of the section whose hocon tag is key. Unknown keys are ignored, as in HOCON files.
+func (s *configSection) set(key, value string) {
+	v := reflect.ValueOf(s).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("hocon") == key {
+			v.Field(i).SetString(value)
+			return
+		}
+	}
+}
+
+// parseJSONSections parses a JSON object of objects, converting the non-string values, such as true or 644, to their text.
+// An object nested in a section is a section too, named by joining the names with a dot, e.g. "alog.http".
+func parseJSONSections(r io.Reader) (map[string]map[string]string, error) {
+	var raw map[string]map[string]interface{}
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	sections := make(map[string]map[string]string, len(raw))
+	for name, values := range raw {
+		addJSONSection(sections, name, values)
+	}
+	return sections, nil
+}
+
+func addJSONSection(sections map[string]map[string]string, name string, values map[string]interface{}) {
+	section := make(map[string]string, len(values))
+	for key, value := range values {
+		if nested, ok := value.(map[string]interface{}); ok {
+			addJSONSection(sections, name+"."+key, nested)
+			continue
+		}
+		section[key] = fmt.Sprint(value)
+	}
+	sections[name] = section
+}
+
+// parseYAMLSections parses the subset of YAML used by logger config files: top level keys each holding
+// a mapping of keys to scalar values, which may be quoted, with # comments.
+// An indented key with no value starts a nested section, named by joining the names with a dot, e.g. "alog.http",
+// which holds the lines indented further than it.
+func parseYAMLSections(r io.Reader) (map[string]map[string]string, error) {
+	sections := make(map[string]map[string]string)
+	var top, section map[string]string
+	var topName string
+	// nestedIndent is the indentation of the key which started the current nested section, or -1 outside of one
+	nestedIndent := -1
+
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if len(trimmed) == 0 || trimmed[0] == '#' || trimmed == "---" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok || len(key) == 0 {
+			return nil, fmt.Errorf("line %d: expected key: value, got %q", lineNo, trimmed)
+		}
+		key = strings.TrimSpace(key)
+		// A key with nothing after it, not even "", holds a mapping
+		mapping := len(strings.TrimSpace(value)) == 0 || strings.HasPrefix(strings.TrimSpace(value), "#")
+		value, err := yamlScalar(value)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent == 0 {
+			if !mapping {
+				return nil, fmt.Errorf("line %d: expected a mapping under %q", lineNo, key)
+			}
+			top, topName, nestedIndent = make(map[string]string), key, -1
+			section = top
+			sections[key] = section
+			continue
+		}
+		if section == nil {
+			return nil, fmt.Errorf("line %d: %q is not inside a section", lineNo, key)
+		}
+		if nestedIndent != -1 && indent <= nestedIndent {
+			section, nestedIndent = top, -1
+		}
+		if mapping && nestedIndent == -1 {
+			section, nestedIndent = make(map[string]string), indent
+			sections[topName+"."+key] = section
+			continue
+		}
+		section[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return sections, nil
+}
+
+// yamlScalar returns the value of a YAML scalar, unquoting it and dropping a trailing comment
+func yamlScalar(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.HasPrefix(s, `"`):
+		quoted, err := strconv.QuotedPrefix(s)
+		if err != nil {
+			return "", fmt.Errorf("malformed quoted value %s", s)
+		}
+		value, _ := strconv.Unquote(quoted)
+		return value, nil
+	case strings.HasPrefix(s, "'"):
+		var sb strings.Builder
+		for i := 1; i < len(s); i++ {
+			if s[i] != '\'' {
+				sb.WriteByte(s[i])
+			} else if i+1 < len(s) && s[i+1] == '\'' {
+				sb.WriteByte('\'')
+				i++
+			} else {
+				return sb.String(), nil
+			}
+		}
+		return "", fmt.Errorf("malformed quoted value %s", s)
+	}
+	if i := strings.Index(s, " #"); i != -1 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
@@ -0,0 +1,108 @@
+package alog
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+)
+
+// Config is a complete configuration of a Logger, which Configure applies all at once.
+// alog.conf is applied through it as well.
+type Config struct {
+	Level LogLevel
+	// Destination is where records are written. It cannot be combined with FileName. With neither, records go to stdout.
+	Destination io.Writer
+	// FileName is the log file to open, with File holding the options it is opened with
+	FileName string
+	File     FileOptions
+	Format   Format
+	// Flags are the output flags, as for SetFlags. Use DefaultFlags for the usual timestamps, 0 leaves them out.
+	Flags int
+	// UTC adds log.LUTC to Flags
+	UTC bool
+}
+
+// validate reports the first setting of c which is out of range or conflicts with another
+func (c *Config) validate() error {
+	if c.Level > OFF {
+		return &InvalidLogLevelError{got: c.Level}
+	}
+	if c.Destination != nil && len(c.FileName) != 0 {
+		return errors.New("alog: both Destination and FileName are set, only one of them can be")
+	}
+	if c.Format > FormatGELF {
+		return fmt.Errorf("alog: invalid Format : %d", c.Format)
+	}
+	if c.File.Rotation > RotateDaily {
+		return fmt.Errorf("alog: invalid Rotation : %d", c.File.Rotation)
+	}
+	if c.File.MaxSize < 0 {
+		return fmt.Errorf("alog: invalid MaxSize : %d", c.File.MaxSize)
+	}
+	if c.File.MaxAgeDays < 0 {
+		return fmt.Errorf("alog: invalid MaxAgeDays : %d", c.File.MaxAgeDays)
+	}
+	return nil
+}
+
+// Configure replaces the level, destination, format and flags of the Logger with those of c.
+// Either all of c is applied or, if c is invalid or its log file cannot be opened, none of it and the error is returned.
+// A log file previously opened by the Logger is closed. Other settings, such as added destinations, are kept.
+// File.Truncate only applies when the file is first opened: reconfiguring the Logger with the log file it already
+// writes, e.g. on every ReloadConfig, appends to the file rather than emptying it.
+func (l *Logger) Configure(c Config) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+	var file io.WriteCloser
+	if c.Destination != nil {
+		out = c.Destination
+	} else if len(c.FileName) != 0 {
+		opts := c.File
+		l.mu.RLock()
+		if l.file != nil && l.fileName == c.FileName {
+			opts.Truncate = false
+		}
+		l.mu.RUnlock()
+		var err error
+		if file, err = openFile(c.FileName, opts); err != nil {
+			return err
+		}
+		out = file
+	}
+
+	flags := c.Flags
+	if c.UTC {
+		flags |= log.LUTC
+	}
+
+	// Holding writeMu keeps records from being written while the files are swapped, as in Reopen
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+
+	l.mu.Lock()
+	previousFile := l.file
+	l.setLogLevel(c.Level)
+	l.disabledLevel = nil
+	l.out, l.file = out, file
+	l.fileName, l.fileOpts = c.FileName, c.File
+	l.applyClock()
+	l.format = c.Format
+	l.flags = flags
+	l.updateOutput()
+	l.mu.Unlock()
+
+	if previousFile != nil {
+		previousFile.Close()
+	}
+	return nil
+}
+
+// Configure replaces the level, destination, format and flags of the default Logger with those of c
+func Configure(c Config) error {
+	return std.Configure(c)
+}
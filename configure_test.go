@@ -0,0 +1,115 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestConfigure(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(os.Stdout, TRACE)
+
+	err := logger.Configure(Config{Level: WARN, Destination: &buf, Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	logger.Info("suppressed")
+	logger.Warn("configured")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil || rec["msg"] != "configured" {
+		t.Errorf("output = %q, %v, want only the WARN record as JSON", buf.String(), err)
+	}
+	if got := logger.GetFlags(); got != 0 {
+		t.Errorf("GetFlags() = %d, want 0", got)
+	}
+}
+
+func TestConfigureFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "logs", "app.log")
+	logger := New(os.Stdout, TRACE)
+	defer logger.Close()
+
+	if err := logger.Configure(Config{Level: INFO, FileName: fileName, Flags: DefaultFlags, UTC: true}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+	logger.Info("to the file")
+	logger.Close()
+
+	if b, err := os.ReadFile(fileName); err != nil || !strings.Contains(string(b), "[INFO] - to the file") {
+		t.Errorf("log file contents = %q, %v, want the INFO record", b, err)
+	}
+}
+
+func TestConfigureInvalid(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, ERROR)
+	logger.SetFlags(0)
+
+	invalid := map[string]Config{
+		"destination and file": {Destination: &bytes.Buffer{}, FileName: "app.log"},
+		"level":                {Level: OFF + 1},
+		"format":               {Format: FormatGELF + 1},
+		"unopenable file":      {FileName: filepath.Join(t.TempDir(), "app.log", "\x00")},
+	}
+	for name, c := range invalid {
+		if err := logger.Configure(c); err == nil {
+			t.Errorf("Configure with invalid %s returned no error", name)
+		}
+	}
+
+	logger.Warn("still suppressed")
+	logger.Error("still configured")
+	if got, want := buf.String(), "- [ERROR] - still configured\n"; got != want {
+		t.Errorf("output after invalid configs = %q, want %q", got, want)
+	}
+}
+
+// TestConfigureConcurrentWithLogging is meant to be run with -race
+func TestConfigureConcurrentWithLogging(t *testing.T) {
+	var stderr bytes.Buffer
+	defer func(out io.Writer) { fallbackOut = out }(fallbackOut)
+	fallbackOut = &stderr
+
+	dir := t.TempDir()
+	logger := New(io.Discard, INFO)
+	defer logger.Close()
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					logger.Info("concurrent record")
+				}
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		fileName := filepath.Join(dir, fmt.Sprintf("app%d.log", i%2))
+		if err := logger.Configure(Config{Level: INFO, FileName: fileName}); err != nil {
+			t.Errorf("Configure() error = %v", err)
+			break
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want no record falling back while the log file is swapped", stderr.String())
+	}
+}
@@ -0,0 +1,55 @@
+package alog
+
+import "context"
+
+// fieldsKey is the context key under which ContextWithFields stores fields
+type fieldsKey struct{}
+
+// ContextWithFields returns a copy of ctx which carries the given fields, merged with those already in ctx.
+// Any Entry created from the context with WithContext includes them, which is how a trace ID is propagated :
+//
+//	ctx = alog.ContextWithFields(ctx, alog.Fields{"trace_id": id})
+//	...
+//	alog.WithContext(ctx).Info("charging card")
+func ContextWithFields(ctx context.Context, f Fields) context.Context {
+	fields := make(Fields, len(f))
+	for k, v := range contextFields(ctx) {
+		fields[k] = v
+	}
+	for k, v := range f {
+		fields[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// contextFields returns the fields stored in ctx by ContextWithFields, if any
+func contextFields(ctx context.Context) Fields {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsKey{}).(Fields)
+	return fields
+}
+
+// WithContext returns an Entry which logs through the Logger with the fields stored in ctx attached
+func (l *Logger) WithContext(ctx context.Context) *Entry {
+	return l.WithFields(contextFields(ctx))
+}
+
+// WithContext returns an Entry which logs through the default Logger with the fields stored in ctx attached
+func WithContext(ctx context.Context) *Entry {
+	return std.WithContext(ctx)
+}
+
+// WithContext returns a new Entry carrying the fields stored in ctx along with the fields of e.
+// Fields already on the Entry, e.g. from WithFields, take precedence over those from the context.
+func (e *Entry) WithContext(ctx context.Context) *Entry {
+	fields := make(Fields, len(e.fields))
+	for k, v := range contextFields(ctx) {
+		fields[k] = v
+	}
+	for k, v := range e.fields {
+		fields[k] = v
+	}
+	return &Entry{logger: e.logger, fields: fields}
+}
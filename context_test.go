@@ -0,0 +1,25 @@
+package alog
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+func TestWithContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	ctx := ContextWithFields(context.Background(), Fields{"trace_id": "4bf92f35", "user": "alice"})
+	ctx = ContextWithFields(ctx, Fields{"span_id": "00f067aa"})
+
+	logger.WithContext(ctx).Info("charging card")
+	logger.WithFields(Fields{"user": "bob"}).WithContext(ctx).Info("overridden")
+
+	want := "- [INFO] - charging card span_id=00f067aa trace_id=4bf92f35 user=alice\n" +
+		"- [INFO] - overridden span_id=00f067aa trace_id=4bf92f35 user=bob\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
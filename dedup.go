@@ -0,0 +1,132 @@
+package alog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// dedupRecord is a record being deduplicated along with the number of times it was repeated since it was written
+type dedupRecord struct {
+	level    LogLevel
+	fields   Fields
+	callSite string
+	msg      string
+	// fieldsKey is the fields rendered as text, so that records with different fields are not deduplicated
+	fieldsKey string
+	repeats   int
+}
+
+// deduper collapses identical records logged within a window into the first record plus a "(repeated N times)" summary
+type deduper struct {
+	mu     sync.Mutex
+	window time.Duration
+	// current is the record being deduplicated, first written at start
+	current dedupRecord
+	start   time.Time
+	timer   *time.Timer
+	// generation counts the windows, so that a timer which fires after its window was ended does not end a later one
+	generation uint64
+}
+
+// admit reports whether the record should be written.
+// A repeat of the current record within the window is counted instead.
+// Any other record ends the window, after writing the summary of the repeats of the current record.
+func (d *deduper) admit(l *Logger, level LogLevel, fields Fields, callSite, msg string) bool {
+	fieldsKey := renderFieldsKey(fields)
+	d.mu.Lock()
+	if d.timer != nil && level == d.current.level && msg == d.current.msg && fieldsKey == d.current.fieldsKey && time.Since(d.start) < d.window {
+		d.current.repeats++
+		d.mu.Unlock()
+		return false
+	}
+	previous := d.end()
+	d.current = dedupRecord{level: level, fields: fields, callSite: callSite, msg: msg, fieldsKey: fieldsKey}
+	d.start = time.Now()
+	d.generation++
+	generation := d.generation
+	d.timer = time.AfterFunc(d.window, func() { d.expire(l, generation) })
+	d.mu.Unlock()
+
+	l.summarize(previous)
+	return true
+}
+
+// renderFieldsKey returns the fields as the sorted " key=value" pairs of text records, leaving out the stack trace
+func renderFieldsKey(fields Fields) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	buf := getBuffer()
+	defer putBuffer(buf)
+	writeTextFields(buf, fields)
+	return buf.String()
+}
+
+// end stops the current window and returns its record. d.mu must be held.
+func (d *deduper) end() dedupRecord {
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	return d.current
+}
+
+// expire ends the window of generation when it closes without the record changing.
+// Timer.Stop does not wait for a timer which already fired, so the window may have been ended meanwhile, in which case
+// expire does nothing.
+func (d *deduper) expire(l *Logger, generation uint64) {
+	d.mu.Lock()
+	if d.timer == nil || generation != d.generation {
+		d.mu.Unlock()
+		return
+	}
+	previous := d.end()
+	d.current = dedupRecord{}
+	d.mu.Unlock()
+
+	l.summarize(previous)
+}
+
+// flush ends the current window, if any, writing the summary of its repeats
+func (d *deduper) flush(l *Logger) {
+	d.mu.Lock()
+	previous := d.end()
+	d.current = dedupRecord{}
+	d.mu.Unlock()
+
+	l.summarize(previous)
+}
+
+// summarize writes the "(repeated N times)" summary of a deduplicated record, if it was repeated at all
+func (l *Logger) summarize(rec dedupRecord) {
+	if rec.repeats == 0 {
+		return
+	}
+	l.emit(l.settings(rec.level), rec.level, rec.fields, rec.callSite, fmt.Sprintf("%s (repeated %d times)", rec.msg, rec.repeats), l.writeRecord)
+}
+
+// SetDedup collapses identical records (same level, expanded message and fields) logged by the Logger within window of the first one.
+// The first record is written immediately; its repeats are replaced by a single "<msg> (repeated N times)" line,
+// written when the window closes or a different record is logged. A window of 0 turns deduplication off.
+func (l *Logger) SetDedup(window time.Duration) {
+	l.mu.Lock()
+	d := l.dedup
+	l.dedup = nil
+	if window > 0 {
+		l.dedup = &deduper{window: window}
+	}
+	l.mu.Unlock()
+
+	if d != nil {
+		d.mu.Lock()
+		previous := d.end()
+		d.mu.Unlock()
+		l.summarize(previous)
+	}
+}
+
+// SetDedup collapses identical records logged by the default Logger within window, see (*Logger).SetDedup
+func SetDedup(window time.Duration) {
+	std.SetDedup(window)
+}
@@ -0,0 +1,89 @@
+package alog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestDedup(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetDedup(time.Hour)
+
+	for i := 0; i < 100; i++ {
+		logger.Errorf("connection refused to %s", "db:5432")
+	}
+	logger.Info("recovered")
+
+	want := "- [ERROR] - connection refused to db:5432\n" +
+		"- [ERROR] - connection refused to db:5432 (repeated 99 times)\n" +
+		"- [INFO] - recovered\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestDedupKeepsDifferentFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetDedup(time.Hour)
+
+	logger.WithFields(Fields{"user": "alice"}).Warn("login failed")
+	logger.WithFields(Fields{"user": "alice"}).Warn("login failed")
+	logger.WithFields(Fields{"user": "bob"}).Warn("login failed")
+	logger.SetDedup(0)
+
+	want := "- [WARN] - login failed user=alice\n" +
+		"- [WARN] - login failed (repeated 1 times) user=alice\n" +
+		"- [WARN] - login failed user=bob\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestDedupWindowCloses(t *testing.T) {
+	var buf lockedBuffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetDedup(20 * time.Millisecond)
+	defer logger.SetDedup(0)
+
+	logger.Warn("spam")
+	logger.Warn("spam")
+	logger.Warn("spam")
+
+	want := "- [WARN] - spam\n- [WARN] - spam (repeated 2 times)\n"
+	deadline := time.Now().Add(5 * time.Second)
+	for buf.String() != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("output = %q, want %q once the window closes", buf.String(), want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDedupLateTimer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetDedup(time.Hour)
+
+	logger.Warn("first")
+	stale := logger.dedup.generation
+	logger.Warn("second")
+	logger.Warn("second")
+	// A timer of the first window which fired before Stop must not end the second one
+	logger.dedup.expire(logger, stale)
+	logger.Warn("second")
+	logger.SetDedup(0)
+
+	want := "- [WARN] - first\n" +
+		"- [WARN] - second\n" +
+		"- [WARN] - second (repeated 2 times)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package alog
+
+import "sort"
+
+// Fields is a set of key/value pairs attached to a log record
+type Fields map[string]interface{}
+
+// keys returns the keys of the fields in sorted order so that records are rendered deterministically
+func (f Fields) keys() []string {
+	keys := make([]string, 0, len(f))
+	for k := range f {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// with returns a copy of the fields with key set to value
+func (f Fields) with(key string, value interface{}) Fields {
+	fields := make(Fields, len(f)+1)
+	for k, v := range f {
+		fields[k] = v
+	}
+	fields[key] = value
+	return fields
+}
+
+// Entry is a log record in the making which carries structured fields.
+// Its Trace/Debug/... methods, and their Tracef/Debugf/... variants, write the message along with the fields, subject to the level of the Logger.
+type Entry struct {
+	logger *Logger
+	fields Fields
+}
+
+// WithFields returns an Entry which logs through the Logger with the given fields attached
+func (l *Logger) WithFields(f Fields) *Entry {
+	e := &Entry{logger: l}
+	return e.WithFields(f)
+}
+
+// WithFields returns an Entry which logs through the default Logger with the given fields attached
+func WithFields(f Fields) *Entry {
+	return std.WithFields(f)
+}
+
+// WithFields returns a new Entry carrying both the fields of e and f. Values in f take precedence.
+func (e *Entry) WithFields(f Fields) *Entry {
+	fields := make(Fields, len(e.fields)+len(f))
+	for k, v := range e.fields {
+		fields[k] = v
+	}
+	for k, v := range f {
+		fields[k] = v
+	}
+	return &Entry{logger: e.logger, fields: fields}
+}
+
+func (e *Entry) Trace(args ...interface{}) {
+	var level LogLevel = TRACE
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, e.logger.sprint(args...))
+	}
+}
+
+func (e *Entry) Tracef(format string, objs ...interface{}) {
+	var level LogLevel = TRACE
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, format, objs...)
+	}
+}
+
+func (e *Entry) Debug(args ...interface{}) {
+	var level LogLevel = DEBUG
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, e.logger.sprint(args...))
+	}
+}
+
+func (e *Entry) Debugf(format string, objs ...interface{}) {
+	var level LogLevel = DEBUG
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, format, objs...)
+	}
+}
+
+func (e *Entry) Info(args ...interface{}) {
+	var level LogLevel = INFO
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, e.logger.sprint(args...))
+	}
+}
+
+func (e *Entry) Infof(format string, objs ...interface{}) {
+	var level LogLevel = INFO
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, format, objs...)
+	}
+}
+
+func (e *Entry) Notice(args ...interface{}) {
+	var level LogLevel = NOTICE
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, e.logger.sprint(args...))
+	}
+}
+
+func (e *Entry) Noticef(format string, objs ...interface{}) {
+	var level LogLevel = NOTICE
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, format, objs...)
+	}
+}
+
+func (e *Entry) Warn(args ...interface{}) {
+	var level LogLevel = WARN
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, e.logger.sprint(args...))
+	}
+}
+
+func (e *Entry) Warnf(format string, objs ...interface{}) {
+	var level LogLevel = WARN
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, format, objs...)
+	}
+}
+
+func (e *Entry) Error(args ...interface{}) {
+	var level LogLevel = ERROR
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, e.logger.sprint(args...))
+	}
+}
+
+func (e *Entry) Errorf(format string, objs ...interface{}) {
+	var level LogLevel = ERROR
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, format, objs...)
+	}
+}
+
+func (e *Entry) Critical(args ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, e.logger.sprint(args...))
+	}
+}
+
+func (e *Entry) Criticalf(format string, objs ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := e.logger.logFunc(level); logFunc != nil {
+		logFunc(e.logger, level, e.fields, format, objs...)
+	}
+}
@@ -0,0 +1,46 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithFieldsText(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+
+	logger.WithFields(Fields{"request_id": "abc123", "user": 42}).Infof("request %s", "served")
+
+	if got := buf.String(); !strings.HasSuffix(got, "[INFO] - request served request_id=abc123 user=42\n") {
+		t.Errorf("output = %q, want message followed by sorted fields", got)
+	}
+}
+
+func TestWithFieldsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFormat(FormatJSON)
+
+	logger.WithFields(Fields{"request_id": "abc123"}).WithFields(Fields{"msg": "ignored"}).Error("failed")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("output %q is not valid JSON: %v", buf.String(), err)
+	}
+	if rec["request_id"] != "abc123" || rec["msg"] != "failed" || rec["level"] != "ERROR" {
+		t.Errorf("record = %v, want fields merged with level and msg", rec)
+	}
+}
+
+func TestWithFieldsSuppressed(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, WARN)
+
+	logger.WithFields(Fields{"k": "v"}).Debug("suppressed")
+
+	if buf.Len() != 0 {
+		t.Errorf("output = %q, want nothing for a suppressed level", buf.String())
+	}
+}
@@ -0,0 +1,63 @@
+package alog
+
+import (
+	"errors"
+	"strings"
+)
+
+// errorChain is an error arg rendered along with the errors it wraps, e.g. "query failed; caused by: connection reset"
+type errorChain struct {
+	err error
+}
+
+// Error writes the causes which are not already part of the message, so that an error wrapped with %w is not repeated
+func (e errorChain) Error() string {
+	var sb strings.Builder
+	sb.WriteString(e.err.Error())
+	for err := errors.Unwrap(e.err); err != nil; err = errors.Unwrap(err) {
+		if strings.Contains(sb.String(), err.Error()) {
+			continue
+		}
+		sb.WriteString("; caused by: ")
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+// withErrorChains returns a copy of objs with the errors which wrap others replaced by their errorChain
+func withErrorChains(objs []interface{}) []interface{} {
+	var chained []interface{}
+	for i, obj := range objs {
+		if err, ok := obj.(error); ok && errors.Unwrap(err) != nil {
+			if chained == nil {
+				chained = append([]interface{}(nil), objs...)
+			}
+			chained[i] = errorChain{err: err}
+		}
+	}
+	if chained == nil {
+		return objs
+	}
+	return chained
+}
+
+func (l *Logger) errorChainEnabled() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.errorChain
+}
+
+// SetErrorChain turns on or off the rendering of wrapped errors. When on, an error arg which wraps another, e.g. one
+// created with fmt.Errorf("...: %w", err), is written followed by each error of its errors.Unwrap chain which its message
+// does not already include, so that Errorf("%v", err) writes "query failed; caused by: connection reset" for an error whose message leaves out its cause.
+// The chain is off by default, leaving errors to be written as fmt formats them.
+func (l *Logger) SetErrorChain(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errorChain = enabled
+}
+
+// SetErrorChain turns on or off the rendering of wrapped errors by the default Logger
+func SetErrorChain(enabled bool) {
+	std.SetErrorChain(enabled)
+}
@@ -0,0 +1,54 @@
+package alog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// causeError is an error which wraps another without including its message
+type causeError struct {
+	msg   string
+	cause error
+}
+
+func (e *causeError) Error() string { return e.msg }
+func (e *causeError) Unwrap() error { return e.cause }
+
+func TestSetErrorChain(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	err := &causeError{msg: "query failed", cause: errors.New("connection reset")}
+	logger.Errorf("%v", err)
+	logger.SetErrorChain(true)
+	logger.Errorf("%v", err)
+	logger.Errorf("%s %d", errors.New("plain"), 2)
+	logger.Error("load: ", err)
+
+	want := "- [ERROR] - query failed\n" +
+		"- [ERROR] - query failed; caused by: connection reset\n" +
+		"- [ERROR] - plain 2\n" +
+		"- [ERROR] - load: query failed; caused by: connection reset\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestSetErrorChainWrapped(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetErrorChain(true)
+
+	inner := errors.New("no such file")
+	err := fmt.Errorf("load config: %w", &causeError{msg: "open alog.conf", cause: inner})
+	logger.Errorf("%v", err)
+
+	want := "- [ERROR] - load config: open alog.conf; caused by: no such file\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,51 @@
+package alog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// fallbackLimit is the number of records per fallbackWindow which are written to fallbackOut while the destination fails
+var fallbackLimit = 10
+
+const fallbackWindow = time.Second
+
+// fallbackOut is where records go when they cannot be written to the destination
+var fallbackOut io.Writer = os.Stderr
+
+// fallback writes the records which the destination of a Logger failed to write to stderr instead,
+// warning once about the failure and then writing at most fallbackLimit records per fallbackWindow
+type fallback struct {
+	mu     sync.Mutex
+	warned bool
+	start  time.Time
+	count  int
+	// dropped is the number of records over the limit in the current window
+	dropped int
+}
+
+func (f *fallback) write(b []byte, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.warned {
+		f.warned = true
+		fmt.Fprintf(fallbackOut, "alog: unable to write to the log destination. Error : %v. Writing records to stderr instead\n", err)
+	}
+
+	if now := time.Now(); now.Sub(f.start) >= fallbackWindow {
+		if f.dropped != 0 {
+			fmt.Fprintf(fallbackOut, "alog: %d records were not written to stderr, to limit its output\n", f.dropped)
+		}
+		f.start, f.count, f.dropped = now, 0, 0
+	}
+	if f.count >= fallbackLimit {
+		f.dropped++
+		return
+	}
+	f.count++
+	fallbackOut.Write(b)
+}
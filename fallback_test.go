@@ -0,0 +1,49 @@
+package alog
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFallbackToStderr(t *testing.T) {
+	var stderr bytes.Buffer
+	defer func(out io.Writer, limit int) {
+		fallbackOut, fallbackLimit = out, limit
+	}(fallbackOut, fallbackLimit)
+	fallbackOut, fallbackLimit = &stderr, 3
+
+	logger := New(failingWriter{}, INFO)
+	logger.SetFlags(0)
+	for i := 0; i < 5; i++ {
+		logger.Errorf("disk full %d", i)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(stderr.String(), "\n"), "\n")
+	want := []string{"- [ERROR] - disk full 0", "- [ERROR] - disk full 1", "- [ERROR] - disk full 2"}
+	if len(lines) != 1+len(want) {
+		t.Fatalf("stderr = %q, want a warning and %d records", stderr.String(), len(want))
+	}
+	if !strings.Contains(lines[0], "unable to write to the log destination") || !strings.Contains(lines[0], "write failed") {
+		t.Errorf("warning = %q, want it to name the write error", lines[0])
+	}
+	for i, w := range want {
+		if lines[1+i] != w {
+			t.Errorf("fallback record %d = %q, want %q", i, lines[1+i], w)
+		}
+	}
+}
+
+func TestNoFallbackWhenWriteSucceeds(t *testing.T) {
+	var stderr, buf bytes.Buffer
+	defer func(out io.Writer) { fallbackOut = out }(fallbackOut)
+	fallbackOut = &stderr
+
+	logger := New(&buf, INFO)
+	logger.Info("fine")
+
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want nothing", stderr.String())
+	}
+}
@@ -0,0 +1,74 @@
+package alog
+
+import "os"
+
+// syncer is implemented by destinations, such as *os.File, which can commit written data to stable storage
+type syncer interface {
+	Sync() error
+}
+
+// sync flushes the destination of the Logger if it supports it
+func (l *Logger) sync() {
+	if s, ok := l.writer().(syncer); ok {
+		s.Sync()
+	}
+}
+
+// fatalMsg writes the final record of Fatal. Unlike logMsg, it is never dropped by SetSampling nor collapsed by SetDedup:
+// the repeats counted so far are summarized first, then the record is written.
+func (l *Logger) fatalMsg(msg string, objs ...interface{}) {
+	var level LogLevel = CRITICAL
+	s := l.settings(level)
+	var fields Fields
+	var callSite string
+	if s.callerInfo {
+		callSite = caller()
+	}
+	if s.stackTrace {
+		fields = fields.with(stackField, stack())
+	}
+	if len(objs) != 0 && s.errorChain {
+		objs = withErrorChains(objs)
+	}
+
+	if s.dedup != nil {
+		s.dedup.flush(l)
+	}
+	msg = redact(s.redactions, expandMsg(msg, objs...))
+	l.emit(s, level, fields, callSite, msg, l.writeRecord)
+	runHooks(s.hooks, level, msg)
+}
+
+// Fatal logs the message at CRITICAL severity, flushes the destination and then terminates the program with os.Exit(1).
+// Fatal messages are never suppressed, regardless of the configured log level, sampling and deduplication.
+func (l *Logger) Fatal(msg string, objs ...interface{}) {
+	l.fatalMsg(msg, objs...)
+	l.sync()
+	os.Exit(1)
+}
+
+// Fatal logs the message through the default Logger and then terminates the program with os.Exit(1)
+func Fatal(msg string, objs ...interface{}) {
+	std.fatalMsg(msg, objs...)
+	std.sync()
+	os.Exit(1)
+}
+
+// Panic logs the message at CRITICAL severity and then panics with the formatted message.
+// The message is written to the destination before the panic unwinds, so a deferred recover can still rely on it being logged.
+func (l *Logger) Panic(msg string, objs ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, msg, objs...)
+	}
+	panic(expandMsg(msg, objs...))
+}
+
+// Panic logs the message through the default Logger and then panics with the formatted message
+func Panic(msg string, objs ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, msg, objs...)
+	}
+	panic(expandMsg(msg, objs...))
+}
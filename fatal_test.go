@@ -0,0 +1,80 @@
+package alog
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestFatal re-runs itself in a subprocess, where it calls Fatal, and checks the exit code of that subprocess
+func TestFatal(t *testing.T) {
+	if os.Getenv("ALOG_TEST_FATAL") == "1" {
+		SetLogDestination(os.Stdout)
+		SetLogLevel(CRITICAL)
+		Fatal("fatal %s", "failure")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatal$")
+	cmd.Env = append(os.Environ(), "ALOG_TEST_FATAL=1")
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("subprocess err = %v, want exit status 1", err)
+	}
+	if !strings.Contains(stdout.String(), "[CRITICAL] - fatal failure") {
+		t.Errorf("subprocess output = %q, want the fatal message", stdout.String())
+	}
+}
+
+// TestFatalNotSampled re-runs itself in a subprocess, where Fatal follows a CRITICAL record while only 1 in 1000 is sampled
+func TestFatalNotSampled(t *testing.T) {
+	if os.Getenv("ALOG_TEST_FATAL") == "sampled" {
+		SetLogDestination(os.Stdout)
+		SetFlags(0)
+		SetSampling(CRITICAL, 1000)
+		SetDedup(time.Hour)
+		Critical("fatal failure")
+		Critical("fatal failure")
+		Fatal("fatal failure")
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatalNotSampled$")
+	cmd.Env = append(os.Environ(), "ALOG_TEST_FATAL=sampled")
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("subprocess err = %v, want exit status 1", err)
+	}
+	if got := strings.Count(stdout.String(), "- [CRITICAL] - fatal failure\n"); got != 2 {
+		t.Errorf("subprocess output = %q, want the sampled CRITICAL record followed by the fatal one", stdout.String())
+	}
+}
+
+func TestPanic(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+
+	defer func() {
+		r := recover()
+		if r != "bad state 42" {
+			t.Errorf("recovered %v, want %q", r, "bad state 42")
+		}
+		if !strings.Contains(buf.String(), "[CRITICAL] - bad state 42") {
+			t.Errorf("output = %q, want the message written before the panic", buf.String())
+		}
+	}()
+
+	logger.Panic("bad state %d", 42)
+}
@@ -0,0 +1,181 @@
+package alog
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// defaultFileMode is the permission bits of log files created by alog, before the umask
+const defaultFileMode os.FileMode = 0666
+
+// FileOptions specifies how a log file is opened and rotated
+type FileOptions struct {
+	// Mode is the permission bits of the file if it is created. 0 means 0666.
+	Mode os.FileMode
+	// Truncate empties an existing file when it is opened, instead of appending to it
+	Truncate bool
+	// Rotation is the policy which decides when the file is rolled over
+	Rotation Rotation
+	// MaxSize is the size in bytes past which the file is rolled over to a numbered file, e.g. app.1.log for app.log,
+	// or app-2024-06-01.1.log with RotateDaily, whichever of the two comes first. 0 means no limit.
+	MaxSize int64
+	// MaxAgeDays removes the rotated out files last modified more than that many days ago, whenever the file is rotated.
+	// 0 keeps them all.
+	MaxAgeDays int
+	// Compress gzip compresses each rotated out file, in the background, to <name>.gz
+	Compress bool
+}
+
+// ParseFileMode parses an octal permission string, such as "0640", as used by the fileMode key in alog.conf
+func ParseFileMode(s string) (os.FileMode, error) {
+	mode, err := strconv.ParseUint(s, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("Invalid File Mode : %s. Valid Values are octal permissions from 0000 to 0777", s)
+	}
+	return os.FileMode(mode), nil
+}
+
+// openLogFile opens the named log file for appending, or truncated if truncate is set,
+// creating it and its parent directories if they do not exist
+func openLogFile(fileName string, mode os.FileMode, truncate bool) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return nil, fmt.Errorf("unable to create log directory : %s. Error : %v", filepath.Dir(fileName), err)
+	}
+	if mode == 0 {
+		mode = defaultFileMode
+	}
+	flag := os.O_APPEND
+	if truncate {
+		flag = os.O_TRUNC
+	}
+	return os.OpenFile(fileName, flag|os.O_CREATE|os.O_WRONLY, mode)
+}
+
+// expandFileName expands ${VAR} and $VAR references to environment variables in the configured file name.
+// Variables which are not set expand to the empty string and are reported on stderr, since they usually lead to an unexpected path.
+func expandFileName(fileName string) string {
+	return os.Expand(fileName, func(name string) string {
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			fmt.Fprintf(os.Stderr, "alog: environment variable %s used in log file name %s is not set\n", name, fileName)
+		}
+		return value
+	})
+}
+
+// openFile opens the named log file according to opts
+func openFile(fileName string, opts FileOptions) (io.WriteCloser, error) {
+	if opts.Rotation == RotateNone && opts.MaxSize == 0 {
+		f, err := openLogFile(fileName, opts.Mode, opts.Truncate)
+		if err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+	r, err := openRotatingFile(fileName, opts)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewFile creates a Logger which appends to the named file and logs messages at or above level.
+// The file is owned by the Logger and is closed by Close.
+func NewFile(fileName string, level LogLevel) (*Logger, error) {
+	return NewFileWithOptions(fileName, level, FileOptions{})
+}
+
+// NewFileWithOptions creates a Logger which writes to the named file, opened and rotated according to opts,
+// and logs messages at or above level. The file is owned by the Logger and is closed by Close.
+func NewFileWithOptions(fileName string, level LogLevel, opts FileOptions) (*Logger, error) {
+	f, err := openFile(fileName, opts)
+	if err != nil {
+		return nil, err
+	}
+	l := New(f, level)
+	l.file, l.fileName, l.fileOpts = f, fileName, opts
+	return l, nil
+}
+
+// Reopen closes the log file opened by the Logger and opens it again by name, appending to it.
+// After an external tool such as logrotate renames the file, Reopen makes the Logger write to a new file of the
+// original name instead of the renamed one. Every record is written to either the old or the new file.
+// It has no effect if the Logger did not open a log file.
+func (l *Logger) Reopen() error {
+	// Holding writeMu keeps records from being written while the files are swapped
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+
+	l.mu.Lock()
+	if l.file == nil {
+		l.mu.Unlock()
+		return nil
+	}
+	opts := l.fileOpts
+	opts.Truncate = false
+	f, err := openFile(l.fileName, opts)
+	if err != nil {
+		l.mu.Unlock()
+		return err
+	}
+	previousFile := l.file
+	// The file only becomes the destination again if it still was, not after SetLogDestination chose another one
+	if l.out == previousFile {
+		l.out = f
+	}
+	l.file = f
+	l.applyClock()
+	l.updateOutput()
+	l.mu.Unlock()
+
+	return previousFile.Close()
+}
+
+// Reopen closes and reopens the log file configured in alog.conf, if any
+func Reopen() error {
+	return std.Reopen()
+}
+
+// Close writes out any queued async records, then flushes and closes the log file opened by the Logger, if any, and the
+// syslog connection opened by SetSyslog.
+// Destinations which were not opened by alog, such as os.Stdout or a writer passed to SetLogDestination, are never closed.
+// Records logged after the log file is closed are written to os.Stdout.
+func (l *Logger) Close() error {
+	l.DisableAsync()
+
+	// Holding writeMu keeps records from being written to the file while it is closed, as in Reopen
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.syslog != nil {
+		l.syslog.Close()
+		l.syslog = nil
+		l.updateOutput()
+	}
+	if l.file == nil {
+		return nil
+	}
+	f := l.file
+	l.file = nil
+	if l.out == f {
+		l.out = os.Stdout
+		l.updateOutput()
+	}
+	if s, ok := f.(syncer); ok {
+		s.Sync()
+	}
+	return f.Close()
+}
+
+// Close flushes and closes the log file configured in alog.conf, if any.
+// It should be deferred in main :
+//
+//	defer alog.Close()
+func Close() error {
+	return std.Close()
+}
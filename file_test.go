@@ -0,0 +1,185 @@
+package alog
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCloseFileLogger(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewFile(fileName, INFO)
+	if err != nil {
+		t.Fatalf("NewFile(%q) error = %v", fileName, err)
+	}
+
+	logger.Info("written before close")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Errorf("second Close() error = %v, want nil", err)
+	}
+
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("unable to read back %q: %v", fileName, err)
+	}
+	if !strings.Contains(string(b), "[INFO] - written before close") {
+		t.Errorf("file contents = %q, want the logged message", b)
+	}
+}
+
+func TestLogAfterClose(t *testing.T) {
+	var stderr bytes.Buffer
+	defer func(out io.Writer) { fallbackOut = out }(fallbackOut)
+	fallbackOut = &stderr
+
+	fileName := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewFile(fileName, INFO)
+	if err != nil {
+		t.Fatalf("NewFile(%q) error = %v", fileName, err)
+	}
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	logger.Info("logged after close")
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want the record written to stdout rather than failing on the closed file", stderr.String())
+	}
+	if got := logger.Config().Destination; got != "stdout" {
+		t.Errorf("Config().Destination after Close = %q, want stdout", got)
+	}
+}
+
+func TestCloseDoesNotCloseStdout(t *testing.T) {
+	logger := New(os.Stdout, INFO)
+	if err := logger.Close(); err != nil {
+		t.Errorf("Close() error = %v, want nil", err)
+	}
+	if _, err := os.Stdout.Stat(); err != nil {
+		t.Errorf("os.Stdout was closed: %v", err)
+	}
+}
+
+func TestNewFileCreatesParentDirectories(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "logs", "nested", "app.log")
+	logger, err := NewFile(fileName, INFO)
+	if err != nil {
+		t.Fatalf("NewFile(%q) error = %v", fileName, err)
+	}
+	logger.Info("in a new directory")
+	logger.Close()
+
+	if b, err := os.ReadFile(fileName); err != nil || !strings.Contains(string(b), "in a new directory") {
+		t.Errorf("file contents = %q, %v, want the logged message", b, err)
+	}
+}
+
+func TestNewFileDirectoryError(t *testing.T) {
+	parent := filepath.Join(t.TempDir(), "not-a-directory")
+	if err := os.WriteFile(parent, nil, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewFile(filepath.Join(parent, "app.log"), INFO); err == nil || !strings.Contains(err.Error(), "unable to create log directory") {
+		t.Errorf("NewFile under a regular file error = %v, want a directory creation error", err)
+	}
+}
+
+func TestFileMode(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "secret.log")
+	mode, err := ParseFileMode("0600")
+	if err != nil {
+		t.Fatalf("ParseFileMode(%q) error = %v", "0600", err)
+	}
+	logger, err := NewFileWithOptions(fileName, INFO, FileOptions{Mode: mode})
+	if err != nil {
+		t.Fatalf("NewFileWithOptions error = %v", err)
+	}
+	logger.Close()
+
+	info, err := os.Stat(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := info.Mode().Perm(); got != 0600 {
+		t.Errorf("file mode = %#o, want 0600", got)
+	}
+
+	for _, s := range []string{"0999", "rw-r--r--", "01777"} {
+		if _, err := ParseFileMode(s); err == nil {
+			t.Errorf("ParseFileMode(%q) returned no error", s)
+		}
+	}
+}
+
+func TestReopenAfterRename(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewFile(fileName, INFO)
+	if err != nil {
+		t.Fatalf("NewFile(%q) error = %v", fileName, err)
+	}
+	defer logger.Close()
+	logger.SetFlags(0)
+
+	logger.Info("before rotation")
+	if err := os.Rename(fileName, fileName+".1"); err != nil {
+		t.Fatal(err)
+	}
+	logger.Info("after rename, before reopen")
+	if err := logger.Reopen(); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	logger.Info("after reopen")
+	logger.Close()
+
+	rotated, _ := os.ReadFile(fileName + ".1")
+	if got, want := string(rotated), "- [INFO] - before rotation\n- [INFO] - after rename, before reopen\n"; got != want {
+		t.Errorf("renamed file contents = %q, want %q", got, want)
+	}
+	current, _ := os.ReadFile(fileName)
+	if got, want := string(current), "- [INFO] - after reopen\n"; got != want {
+		t.Errorf("reopened file contents = %q, want %q", got, want)
+	}
+}
+
+func TestReopenWithoutFile(t *testing.T) {
+	logger := New(os.Stdout, INFO)
+	if err := logger.Reopen(); err != nil {
+		t.Errorf("Reopen() without a log file error = %v, want nil", err)
+	}
+	if logger.GetLogDestination() != os.Stdout {
+		t.Errorf("destination after Reopen = %v, want os.Stdout kept", logger.GetLogDestination())
+	}
+}
+
+func TestReopenKeepsSetLogDestination(t *testing.T) {
+	var buf bytes.Buffer
+	fileName := filepath.Join(t.TempDir(), "app.log")
+	logger, err := NewFile(fileName, INFO)
+	if err != nil {
+		t.Fatalf("NewFile(%q) error = %v", fileName, err)
+	}
+	defer logger.Close()
+	logger.SetFlags(0)
+
+	logger.SetLogDestination(&buf)
+	if err := logger.Reopen(); err != nil {
+		t.Fatalf("Reopen() error = %v", err)
+	}
+	logger.Info("after reopen")
+
+	if got := logger.GetLogDestination(); got != &buf {
+		t.Errorf("destination after Reopen = %v, want the buffer set with SetLogDestination", got)
+	}
+	if !strings.Contains(buf.String(), "after reopen") {
+		t.Errorf("buffer contents = %q, want the record logged after Reopen", buf.String())
+	}
+	if b, _ := os.ReadFile(fileName); len(b) != 0 {
+		t.Errorf("log file contents = %q, want nothing written after SetLogDestination", b)
+	}
+}
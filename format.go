@@ -0,0 +1,308 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format is the type used to specify the layout of the log records
+type Format uint8
+
+// The format constants specify the record layouts which can be written
+const (
+	// FormatText writes records as "<timestamp> - [LEVEL] - msg". This is the default.
+	FormatText Format = iota
+	// FormatJSON writes each record as a JSON object with "time", "level" and "msg" fields
+	FormatJSON
+	// FormatLogfmt writes each record as space separated key=value pairs, e.g. time=... level=info msg="disk full"
+	FormatLogfmt
+	// FormatGELF writes each record as a Graylog Extended Log Format (GELF 1.1) JSON object, with the fields as additional "_key" fields
+	FormatGELF
+)
+
+// expandMsg returns the message with the objs expanded Printf style, or the message verbatim if there are no objs
+func expandMsg(msg string, objs ...interface{}) string {
+	if len(objs) > 0 {
+		return fmt.Sprintf(msg, objs...)
+	}
+	return msg
+}
+
+// sprint returns the args formatted as fmt.Sprint does, with the error chains expanded if SetErrorChain is on
+// args is left unmodified so that go vet treats Trace..Critical as print wrappers
+func (l *Logger) sprint(args ...interface{}) string {
+	if len(args) != 0 && l.errorChainEnabled() {
+		return fmt.Sprint(withErrorChains(args)...)
+	}
+	return fmt.Sprint(args...)
+}
+
+// sprintln returns the args formatted as fmt.Sprintln does, without the trailing newline
+func sprintln(args ...interface{}) string {
+	msg := fmt.Sprintln(args...)
+	return msg[:len(msg)-1]
+}
+
+// bufferPool holds the buffers records are built in, to save allocating one for every record
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// maxPooledBuffer is the capacity above which a buffer is dropped rather than returned to bufferPool,
+// so that one huge record does not keep its buffer alive
+const maxPooledBuffer = 64 << 10
+
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer returns buf to bufferPool. Records are never retained after the Write to the destination, nor by
+// the async writer, which copies them, so buf can be reused as soon as the record has been written.
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBuffer {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
+
+// writeTextFields appends the fields as " key=value" pairs, sorted by key, leaving out the stack trace
+func writeTextFields(sb *bytes.Buffer, fields Fields) {
+	for _, k := range fields.keys() {
+		if _, ok := fields[k].(stackTrace); ok {
+			continue
+		}
+		sb.WriteByte(' ')
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		fmt.Fprint(sb, fields[k])
+	}
+}
+
+// logJSON passes a single JSON encoded record to out.
+// Fields are merged into the object, but never override the "time", "level" and "msg" fields.
+func (l *Logger) logJSON(s recordSettings, level LogLevel, fields Fields, msg string, out func(LogLevel, []byte)) {
+	rec := make(map[string]interface{}, len(fields)+3)
+	for k, v := range fields {
+		rec[k] = jsonValue(v)
+	}
+	if s.stamp {
+		rec["time"] = s.now().Format(time.RFC3339Nano)
+	}
+	rec["level"] = level.String()
+	rec["msg"] = msg
+
+	b, err := marshalRecord(rec)
+	if err != nil {
+		l.fallback.write(append([]byte(msg), s.ending...), err)
+		return
+	}
+	b = append(b, s.ending...)
+	out(level, b)
+}
+
+// jsonValue returns a field value as it is encoded in a JSON record. Errors and fmt.Stringers, which encoding/json would write
+// as {} or as their struct fields, become their text, unless they encode themselves with MarshalJSON, as time.Time does.
+func jsonValue(v interface{}) interface{} {
+	switch v.(type) {
+	case json.Marshaler:
+		return v
+	case error, fmt.Stringer:
+		return fmt.Sprint(v)
+	}
+	return v
+}
+
+// marshalRecord encodes rec as a JSON object. The values which encoding/json cannot encode, such as NaN, channels or funcs,
+// are encoded as their fmt.Sprint text rather than losing the whole record.
+func marshalRecord(rec map[string]interface{}) ([]byte, error) {
+	b, err := json.Marshal(rec)
+	if err == nil {
+		return b, nil
+	}
+	for k, v := range rec {
+		if _, err := json.Marshal(v); err != nil {
+			rec[k] = fmt.Sprint(v)
+		}
+	}
+	return json.Marshal(rec)
+}
+
+// logfmtValue returns v as a logfmt value, quoted if it is empty or contains spaces, quotes, '=' or control characters
+func logfmtValue(v interface{}) string {
+	s := fmt.Sprint(v)
+	if len(s) == 0 || strings.IndexFunc(s, func(r rune) bool {
+		return r <= ' ' || r == '"' || r == '=' || r == '\\' || r == 0x7f
+	}) != -1 {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// logLogfmt passes a single logfmt encoded record, with the fields following the msg, to out
+func (l *Logger) logLogfmt(s recordSettings, level LogLevel, fields Fields, msg string, out func(LogLevel, []byte)) {
+	sb := getBuffer()
+	defer putBuffer(sb)
+	if s.stamp {
+		sb.WriteString("time=")
+		sb.Write(s.now().AppendFormat(sb.AvailableBuffer(), time.RFC3339Nano))
+		sb.WriteByte(' ')
+	}
+	sb.WriteString("level=")
+	sb.WriteString(strings.ToLower(level.String()))
+	sb.WriteString(" msg=")
+	sb.WriteString(logfmtValue(msg))
+	for _, k := range fields.keys() {
+		sb.WriteByte(' ')
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(logfmtValue(fields[k]))
+	}
+	sb.WriteString(s.ending)
+	out(level, sb.Bytes())
+}
+
+// Formatter renders an entire record, without the trailing newline, from its level, time and expanded message.
+// Structured fields, if any, are appended to the message as key=value pairs.
+type Formatter func(level LogLevel, t time.Time, msg string) string
+
+// DefaultFormatter reproduces the default text layout, e.g. "2018/11/07 18:03:25.123456 - [ERROR] - msg".
+// It can be used as a starting point for, or a fallback of, custom formatters.
+func DefaultFormatter(level LogLevel, t time.Time, msg string) string {
+	return t.Format("2006/01/02 15:04:05.000000") + " - " + logLevelIntToStringMap[level] + " - " + msg
+}
+
+// endLine terminates the record in buf with ending, in place of the newline, or CRLF, the message already ends with, if any,
+// so that every record ends with exactly one line ending
+func endLine(buf *bytes.Buffer, ending string) {
+	b := buf.Bytes()
+	if bytes.HasSuffix(b, []byte("\r\n")) {
+		buf.Truncate(len(b) - 2)
+	} else if bytes.HasSuffix(b, []byte("\n")) {
+		buf.Truncate(len(b) - 1)
+	}
+	buf.WriteString(ending)
+}
+
+// trimLineEnd returns msg without the newline, or CRLF, it ends with, if any, so that the fields written after it stay on its line
+func trimLineEnd(msg string) string {
+	if strings.HasSuffix(msg, "\r\n") {
+		return msg[:len(msg)-2]
+	}
+	return strings.TrimSuffix(msg, "\n")
+}
+
+// SetLineEnding sets the line ending which terminates every record written by the Logger, e.g. "\r\n" for Windows consumers.
+// The default is "\n". A message which already ends with a newline is not given a second one. An empty ending restores the default.
+func (l *Logger) SetLineEnding(ending string) {
+	if len(ending) == 0 {
+		ending = "\n"
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lineEnding = ending
+}
+
+// SetLineEnding sets the line ending which terminates every record written by the default Logger
+func SetLineEnding(ending string) {
+	std.SetLineEnding(ending)
+}
+
+// appendHeader appends the timestamp of a text record at t to b, laid out as the standard library's log package does for flags
+func appendHeader(b []byte, t time.Time, flags int) []byte {
+	if flags&log.LUTC != 0 {
+		t = t.UTC()
+	}
+	if flags&log.Ldate != 0 {
+		b = t.AppendFormat(b, "2006/01/02 ")
+	}
+	if flags&(log.Ltime|log.Lmicroseconds) != 0 {
+		b = t.AppendFormat(b, "15:04:05")
+		if flags&log.Lmicroseconds != 0 {
+			b = t.AppendFormat(b, ".000000")
+		}
+		b = append(b, ' ')
+	}
+	return b
+}
+
+// writer returns where the Logger currently writes its records
+func (l *Logger) writer() io.Writer {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.w
+}
+
+// writeRecord writes a complete record at level, including its trailing newline, to the destinations of the Logger, of which
+// syslog is one if SetSyslog is on, with a single Write call each, and to the ring buffer if EnableRingBuffer is on. If the destination fails, the record is written
+// to stderr instead. Records written concurrently from several goroutines are serialized, even when the
+// destination is not safe for concurrent use.
+func (l *Logger) writeRecord(level LogLevel, b []byte) {
+	if err := l.tryWriteRecord(level, b); err != nil {
+		l.fallback.write(b, err)
+	}
+}
+
+// tryWriteRecord writes a complete record as writeRecord does, but returns the error of the destination instead of
+// writing the record to stderr
+func (l *Logger) tryWriteRecord(level LogLevel, b []byte) error {
+	// writeMu is taken first, so that Configure and Reopen cannot close the destination read below before it is written
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+
+	l.mu.RLock()
+	w, ring := l.w, l.ring
+	l.mu.RUnlock()
+	err := routeRecord(w, level, b)
+	if ring != nil {
+		ring.write(level, b)
+	}
+	return err
+}
+
+// logFormatted passes a record rendered by a custom Formatter to out
+func (l *Logger) logFormatted(s recordSettings, level LogLevel, fields Fields, msg string, out func(LogLevel, []byte)) {
+	buf := getBuffer()
+	defer putBuffer(buf)
+	buf.WriteString(trimLineEnd(msg))
+	writeTextFields(buf, fields)
+
+	line := s.formatter(level, s.now(), buf.String())
+	buf.Reset()
+	buf.WriteString(line)
+	endLine(buf, s.ending)
+	writeStackTrace(buf, fields, s.ending)
+	out(level, buf.Bytes())
+}
+
+// SetFormatter makes the Logger render each record with f, which takes precedence over the Format.
+// alog writes the returned line, followed by a newline, to the destination. A nil f restores the Format.
+func (l *Logger) SetFormatter(f Formatter) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.formatter = f
+}
+
+// SetFormatter makes the default Logger render each record with f
+func SetFormatter(f Formatter) {
+	std.SetFormatter(f)
+}
+
+// SetFormat sets the layout of the records written by the Logger
+func (l *Logger) SetFormat(format Format) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.format = format
+}
+
+// SetFormat sets the layout of the records written by the default Logger
+func SetFormat(format Format) {
+	std.SetFormat(format)
+}
@@ -0,0 +1,187 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFormat(FormatJSON)
+
+	logger.Warnf("disk %d%% full", 91)
+
+	var rec map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("output %q is not valid JSON: %v", buf.String(), err)
+	}
+	if rec["level"] != "WARN" {
+		t.Errorf("level = %q, want WARN", rec["level"])
+	}
+	if rec["msg"] != "disk 91% full" {
+		t.Errorf("msg = %q, want %q", rec["msg"], "disk 91% full")
+	}
+	if _, err := time.Parse(time.RFC3339Nano, rec["time"]); err != nil {
+		t.Errorf("time = %q does not parse as RFC3339: %v", rec["time"], err)
+	}
+}
+
+func TestFormatJSONFieldValues(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFormat(FormatJSON)
+
+	logger.WithFields(Fields{
+		"err":     errors.New("connection reset"),
+		"elapsed": 1500 * time.Millisecond,
+		"ratio":   math.NaN(),
+		"ch":      make(chan int),
+		"count":   3,
+	}).Error("request failed")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("output %q is not valid JSON: %v", buf.String(), err)
+	}
+	for k, want := range map[string]interface{}{"err": "connection reset", "elapsed": "1.5s", "ratio": "NaN", "count": 3.0, "msg": "request failed"} {
+		if rec[k] != want {
+			t.Errorf("%s = %#v, want %#v", k, rec[k], want)
+		}
+	}
+	if _, ok := rec["ch"].(string); !ok {
+		t.Errorf("ch = %#v, want the channel written as text", rec["ch"])
+	}
+}
+
+func TestSetFormatter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFormatter(func(level LogLevel, t time.Time, msg string) string {
+		return strings.Join([]string{t.Format("2006-01-02"), level.String(), strconv.Quote(msg)}, ",")
+	})
+
+	logger.WithFields(Fields{"id": 7}).Errorf("order %s failed", "A-1")
+
+	want := time.Now().Format("2006-01-02") + `,ERROR,"order A-1 failed id=7"` + "\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestDefaultFormatter(t *testing.T) {
+	ts := time.Date(2018, 11, 7, 18, 3, 25, 123456000, time.UTC)
+	if got, want := DefaultFormatter(ERROR, ts, "msg"), "2018/11/07 18:03:25.123456 - [ERROR] - msg"; got != want {
+		t.Errorf("DefaultFormatter() = %q, want %q", got, want)
+	}
+}
+
+// parseLogfmt splits a logfmt line into its key/value pairs, unquoting quoted values
+func parseLogfmt(t *testing.T, line string) map[string]string {
+	t.Helper()
+	pairs := make(map[string]string)
+	for line = strings.TrimSpace(line); len(line) != 0; line = strings.TrimLeft(line, " ") {
+		eq := strings.IndexByte(line, '=')
+		if eq <= 0 {
+			t.Fatalf("malformed logfmt at %q", line)
+		}
+		key, rest := line[:eq], line[eq+1:]
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				t.Fatalf("malformed quoted value at %q: %v", rest, err)
+			}
+			value, _ = strconv.Unquote(quoted)
+			rest = rest[len(quoted):]
+		} else if sp := strings.IndexByte(rest, ' '); sp >= 0 {
+			value, rest = rest[:sp], rest[sp:]
+		} else {
+			value, rest = rest, ""
+		}
+		pairs[key] = value
+		line = rest
+	}
+	return pairs
+}
+
+func TestFormatLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFormat(FormatLogfmt)
+
+	logger.WithFields(Fields{"user": "alice", "query": `name = "bob"`}).Infof("user said %q", "hi there")
+
+	pairs := parseLogfmt(t, buf.String())
+	if pairs["level"] != "info" {
+		t.Errorf("level = %q, want info", pairs["level"])
+	}
+	if want := `user said "hi there"`; pairs["msg"] != want {
+		t.Errorf("msg = %q, want %q", pairs["msg"], want)
+	}
+	if pairs["user"] != "alice" || pairs["query"] != `name = "bob"` {
+		t.Errorf("fields = %v, want user and query", pairs)
+	}
+	if _, err := time.Parse(time.RFC3339Nano, pairs["time"]); err != nil {
+		t.Errorf("time = %q does not parse: %v", pairs["time"], err)
+	}
+}
+
+func TestLineEnding(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	logger.Info("no newline")
+	logger.Info("one newline\n")
+	logger.Info("crlf\r\n")
+	if got, want := buf.String(), "- [INFO] - no newline\n- [INFO] - one newline\n- [INFO] - crlf\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	logger.SetLineEnding("\r\n")
+	logger.Info("no newline")
+	logger.Info("one newline\n")
+	logger.Info("crlf\r\n")
+	if got, want := buf.String(), "- [INFO] - no newline\r\n- [INFO] - one newline\r\n- [INFO] - crlf\r\n"; got != want {
+		t.Errorf("output with CRLF = %q, want %q", got, want)
+	}
+
+	for _, format := range []Format{FormatJSON, FormatLogfmt} {
+		buf.Reset()
+		logger.SetFormat(format)
+		logger.Info("structured")
+		if got := buf.String(); !strings.HasSuffix(got, "\r\n") || strings.Count(got, "\n") != 1 {
+			t.Errorf("format %d output with CRLF = %q, want a single CRLF at the end", format, got)
+		}
+	}
+
+	buf.Reset()
+	logger.SetFormat(FormatText)
+	logger.SetLineEnding("")
+	logger.Info("default again")
+	if got, want := buf.String(), "- [INFO] - default again\n"; got != want {
+		t.Errorf("output after SetLineEnding(\"\") = %q, want %q", got, want)
+	}
+}
+
+func TestLineEndingBeforeFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	logger.WithFields(Fields{"k": "v"}).Info("one newline\n")
+	logger.WithFields(Fields{"k": "v"}).Info("crlf\r\n")
+	logger.SetFormatter(func(level LogLevel, t time.Time, msg string) string { return msg })
+	logger.WithFields(Fields{"k": "v"}).Info("formatted\n")
+	if got, want := buf.String(), "- [INFO] - one newline k=v\n- [INFO] - crlf k=v\nformatted k=v\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
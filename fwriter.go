@@ -0,0 +1,87 @@
+package alog
+
+import "io"
+
+// The F functions write a single record to the given writer instead of the destinations of the Logger, as fmt.Fprintf does,
+// for one-off redirection such as capturing the output of a specific call. The record is formatted and level gated as for
+// the destinations, but is not counted by Stats, passed to hooks, deduplicated or kept in the ring buffer.
+
+// logTo formats a record and writes it to w, if level is enabled
+func (l *Logger) logTo(w io.Writer, level LogLevel, msg string, objs ...interface{}) {
+	if !l.IsEnabled(level) {
+		return
+	}
+
+	s := l.settings(level)
+	var callSite string
+	if s.callerInfo {
+		callSite = caller()
+	}
+	var fields Fields
+	if s.stackTrace {
+		fields = fields.with(stackField, stack())
+	}
+	if len(objs) != 0 && s.errorChain {
+		objs = withErrorChains(objs)
+	}
+
+	l.render(s, level, fields, callSite, redact(s.redactions, expandMsg(msg, objs...)), func(_ LogLevel, b []byte) {
+		w.Write(b)
+	})
+}
+
+func (l *Logger) Ftrace(w io.Writer, msg string, objs ...interface{}) {
+	l.logTo(w, TRACE, msg, objs...)
+}
+
+func (l *Logger) Fdebug(w io.Writer, msg string, objs ...interface{}) {
+	l.logTo(w, DEBUG, msg, objs...)
+}
+
+func (l *Logger) Finfo(w io.Writer, msg string, objs ...interface{}) {
+	l.logTo(w, INFO, msg, objs...)
+}
+
+func (l *Logger) Fnotice(w io.Writer, msg string, objs ...interface{}) {
+	l.logTo(w, NOTICE, msg, objs...)
+}
+
+func (l *Logger) Fwarn(w io.Writer, msg string, objs ...interface{}) {
+	l.logTo(w, WARN, msg, objs...)
+}
+
+func (l *Logger) Ferror(w io.Writer, msg string, objs ...interface{}) {
+	l.logTo(w, ERROR, msg, objs...)
+}
+
+func (l *Logger) Fcritical(w io.Writer, msg string, objs ...interface{}) {
+	l.logTo(w, CRITICAL, msg, objs...)
+}
+
+func Ftrace(w io.Writer, msg string, objs ...interface{}) {
+	std.logTo(w, TRACE, msg, objs...)
+}
+
+func Fdebug(w io.Writer, msg string, objs ...interface{}) {
+	std.logTo(w, DEBUG, msg, objs...)
+}
+
+func Finfo(w io.Writer, msg string, objs ...interface{}) {
+	std.logTo(w, INFO, msg, objs...)
+}
+
+func Fnotice(w io.Writer, msg string, objs ...interface{}) {
+	std.logTo(w, NOTICE, msg, objs...)
+}
+
+func Fwarn(w io.Writer, msg string, objs ...interface{}) {
+	std.logTo(w, WARN, msg, objs...)
+}
+
+func Ferror(w io.Writer, msg string, objs ...interface{}) {
+	std.logTo(w, ERROR, msg, objs...)
+}
+
+func Fcritical(w io.Writer, msg string, objs ...interface{}) {
+	std.logTo(w, CRITICAL, msg, objs...)
+}
@@ -0,0 +1,39 @@
+package alog
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestFinfo(t *testing.T) {
+	var dest, buf bytes.Buffer
+	logger := New(&dest, INFO)
+	logger.SetFlags(0)
+
+	logger.Finfo(&buf, "captured %d of %d", 1, 2)
+	logger.Fdebug(&buf, "suppressed")
+	logger.Fcritical(&buf, "also captured")
+
+	if got, want := buf.String(), "- [INFO] - captured 1 of 2\n- [CRITICAL] - also captured\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if dest.Len() != 0 {
+		t.Errorf("destination = %q, want nothing", dest.String())
+	}
+	if got := logger.Stats()[INFO]; got != 0 {
+		t.Errorf("INFO count = %d, want 0", got)
+	}
+}
+
+func TestFinfoCallerInfo(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&bytes.Buffer{}, INFO)
+	logger.SetFlags(0)
+	logger.SetCallerInfo(true)
+
+	logger.Fwarn(&buf, "where")
+	if got := buf.String(); !strings.Contains(got, "fwriter_test.go:") {
+		t.Errorf("output = %q, want the call site in fwriter_test.go", got)
+	}
+}
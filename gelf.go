@@ -0,0 +1,52 @@
+package alog
+
+import "strings"
+
+// gelfVersion is the version of the GELF specification the records conform to
+const gelfVersion = "1.1"
+
+// gelfLevelMap maps each level to its syslog severity, which GELF uses as the level of a record
+var gelfLevelMap = map[LogLevel]int{
+	TRACE:    7,
+	DEBUG:    7,
+	INFO:     6,
+	NOTICE:   5,
+	WARN:     4,
+	ERROR:    3,
+	CRITICAL: 2,
+}
+
+// logGELF passes a single GELF encoded record to out. The host is always set, so the "host" field added by
+// SetIncludeHostname is left out, and a stack trace becomes the full_message.
+// The other fields become additional fields, prefixed with an underscore, except "id", which GELF reserves.
+// The timestamp is in seconds since the epoch, with millisecond precision.
+func (l *Logger) logGELF(s recordSettings, level LogLevel, fields Fields, msg string, out func(LogLevel, []byte)) {
+	rec := make(map[string]interface{}, len(fields)+5)
+	for k, v := range fields {
+		switch k {
+		case "host", "id":
+			continue
+		case stackField:
+			if st, ok := v.(stackTrace); ok {
+				rec["full_message"] = strings.TrimRight(string(st), "\n")
+				continue
+			}
+		}
+		rec["_"+k] = jsonValue(v)
+	}
+	rec["version"] = gelfVersion
+	rec["host"] = hostname
+	rec["short_message"] = msg
+	rec["level"] = gelfLevelMap[level]
+	if s.stamp {
+		rec["timestamp"] = float64(s.now().UnixMilli()) / 1000
+	}
+
+	b, err := marshalRecord(rec)
+	if err != nil {
+		l.fallback.write(append([]byte(msg), s.ending...), err)
+		return
+	}
+	b = append(b, s.ending...)
+	out(level, b)
+}
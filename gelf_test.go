@@ -0,0 +1,79 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatGELF(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFormat(FormatGELF)
+	logger.SetClock(func() time.Time { return time.Unix(1541613805, 123000000) })
+
+	logger.WithFields(Fields{"user": "alice", "id": 7}).Warnf("disk %s full", "sda")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("record %q is not JSON : %v", buf.String(), err)
+	}
+	want := map[string]interface{}{
+		"version":       "1.1",
+		"host":          hostname,
+		"short_message": "disk sda full",
+		"level":         4.0,
+		"timestamp":     1541613805.123,
+		"_user":         "alice",
+	}
+	for k, v := range want {
+		if rec[k] != v {
+			t.Errorf("%s = %v, want %v", k, rec[k], v)
+		}
+	}
+	if len(rec) != len(want) {
+		t.Errorf("record = %v, want only the keys of %v", rec, want)
+	}
+}
+
+func TestGELFFieldValues(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFormat(FormatGELF)
+
+	logger.WithFields(Fields{"err": errors.New("connection reset"), "ratio": math.Inf(1)}).Error("request failed")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("record %q is not JSON : %v", buf.String(), err)
+	}
+	if rec["_err"] != "connection reset" || rec["_ratio"] != "+Inf" || rec["short_message"] != "request failed" {
+		t.Errorf("record = %v, want the error and the infinite ratio written as text", rec)
+	}
+}
+
+func TestGELFLevels(t *testing.T) {
+	want := map[LogLevel]float64{TRACE: 7, DEBUG: 7, INFO: 6, NOTICE: 5, WARN: 4, ERROR: 3, CRITICAL: 2}
+	for level, severity := range want {
+		var buf bytes.Buffer
+		logger := New(&buf, TRACE)
+		logger.SetFormat(FormatGELF)
+		logger.SetStackTrace(CRITICAL)
+		logger.LogBytes(level, []byte("msg"))
+
+		var rec map[string]interface{}
+		if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+			t.Fatalf("record %q is not JSON : %v", buf.String(), err)
+		}
+		if rec["level"] != severity {
+			t.Errorf("GELF level of %v = %v, want %v", level, rec["level"], severity)
+		}
+		if full, _ := rec["full_message"].(string); (level == CRITICAL) != strings.Contains(full, "goroutine") {
+			t.Errorf("full_message of %v = %q, want the stack trace only at CRITICAL", level, full)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package alog
+
+// hook is a function added with AddHook, along with the level from which it is called
+type hook struct {
+	minLevel LogLevel
+	fn       func(level LogLevel, msg string)
+}
+
+// runHooks calls, in order, the hooks for records at level
+func runHooks(hooks []hook, level LogLevel, msg string) {
+	for _, h := range hooks {
+		if level >= h.minLevel {
+			h.fn(level, msg)
+		}
+	}
+}
+
+// AddHook makes the Logger call fn for every record at or above minLevel, with the level and the expanded message,
+// once the record has been written. It can be used, for example, to raise an alert on CRITICAL records.
+// Hooks are called synchronously, in the order they were added, by the goroutine which logged the record,
+// so a slow hook slows down logging; fn can start a goroutine of its own for slow work.
+// Records suppressed by the level, sampling or deduplication do not reach the hooks.
+func (l *Logger) AddHook(minLevel LogLevel, fn func(level LogLevel, msg string)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	hooks := make([]hook, len(l.hooks), len(l.hooks)+1)
+	copy(hooks, l.hooks)
+	l.hooks = append(hooks, hook{minLevel: minLevel, fn: fn})
+}
+
+// AddHook makes the default Logger call fn for every record at or above minLevel
+func AddHook(minLevel LogLevel, fn func(level LogLevel, msg string)) {
+	std.AddHook(minLevel, fn)
+}
@@ -0,0 +1,34 @@
+package alog
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestAddHook(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+
+	var calls []string
+	logger.AddHook(ERROR, func(level LogLevel, msg string) {
+		calls = append(calls, fmt.Sprintf("first %v %s", level, msg))
+	})
+	logger.AddHook(TRACE, func(level LogLevel, msg string) {
+		calls = append(calls, fmt.Sprintf("second %v %s", level, msg))
+	})
+
+	logger.Debug("suppressed by the level")
+	logger.Infof("started %d workers", 4)
+	logger.WithFields(Fields{"disk": "sda"}).Critical("disk failed")
+
+	want := []string{
+		"second INFO started 4 workers",
+		"first CRITICAL disk failed",
+		"second CRITICAL disk failed",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("hook calls = %q, want %q", calls, want)
+	}
+}
@@ -0,0 +1,110 @@
+package alog
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// statusRecorder is an http.ResponseWriter which records the status code written by the handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Flush sends the buffered data to the client, so that streaming handlers, e.g. of server-sent events, keep working behind
+// the middleware. It is a no-op if the wrapped writer does not support flushing.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		if r.status == 0 {
+			r.status = http.StatusOK
+		}
+		f.Flush()
+	}
+}
+
+// Unwrap returns the wrapped writer, through which http.NewResponseController reaches its Hijack, SetWriteDeadline, etc.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// HTTPHandler returns a middleware which logs each request served by next through the Logger, as
+// "<method> <path> <status> <duration>". Requests are logged at INFO, or at ERROR when the status is 5xx.
+// The records carry no caller info, even with SetCallerInfo on, as they are not logged from the user's code.
+func (l *Logger) HTTPHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, r)
+
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+		level := INFO
+		if rec.status >= 500 {
+			level = ERROR
+		}
+		if logFunc := l.logFunc(level); logFunc != nil {
+			logFunc(l, level, nil, "%s %s %d %s", r.Method, r.URL.Path, rec.status, time.Since(start))
+		}
+	})
+}
+
+// HTTPHandler returns a middleware which logs each request served by next through the default Logger
+func HTTPHandler(next http.Handler) http.Handler {
+	return std.HTTPHandler(next)
+}
+
+// LevelHandler returns an http.Handler which reads and changes the level of the Logger at runtime.
+// GET responds with the current level as JSON, e.g. {"level":"INFO"}.
+// PUT or POST with a level name as the body, e.g. DEBUG, applies it and responds with the new level; an invalid name is rejected with 400.
+// It is typically mounted at e.g. /debug/loglevel.
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			body, err := io.ReadAll(io.LimitReader(r.Body, 64))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			level, err := ParseLevel(strings.TrimSpace(string(body)))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			l.SetLogLevel(level)
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Level LogLevel `json:"level"`
+		}{l.GetLogLevel()})
+	})
+}
+
+// LevelHandler returns an http.Handler which reads and changes the level of the default Logger at runtime
+func LevelHandler() http.Handler {
+	return std.LevelHandler()
+}
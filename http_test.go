@@ -0,0 +1,109 @@
+package alog
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHTTPHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("hello")) })
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusBadGateway) })
+	handler := logger.HTTPHandler(mux)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/fail", nil))
+
+	want := regexp.MustCompile(`^- \[INFO\] - GET /ok 200 \S+s\n- \[ERROR\] - POST /fail 502 \S+s\n$`)
+	if got := buf.String(); !want.MatchString(got) {
+		t.Errorf("output = %q, want it to match %v", got, want)
+	}
+}
+
+func TestHTTPHandlerNoCallerInfo(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetCallerInfo(true)
+
+	handler := logger.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ok", nil))
+
+	if got := buf.String(); !strings.HasPrefix(got, "- [INFO] - GET /ok 200 ") {
+		t.Errorf("output = %q, want a record without caller info", got)
+	}
+}
+
+// deadlineRecorder is a ResponseWriter which supports write deadlines, as the writers of net/http servers do
+type deadlineRecorder struct {
+	*httptest.ResponseRecorder
+	deadline time.Time
+}
+
+func (r *deadlineRecorder) SetWriteDeadline(deadline time.Time) error {
+	r.deadline = deadline
+	return nil
+}
+
+func TestHTTPHandlerStreaming(t *testing.T) {
+	logger := New(&bytes.Buffer{}, INFO)
+	deadline := time.Now().Add(time.Minute)
+	handler := logger.HTTPHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f, ok := w.(http.Flusher)
+		if !ok {
+			t.Fatal("ResponseWriter behind the middleware is not an http.Flusher")
+		}
+		w.Write([]byte("data: event\n\n"))
+		f.Flush()
+		if err := http.NewResponseController(w).SetWriteDeadline(deadline); err != nil {
+			t.Errorf("SetWriteDeadline() error = %v, want it to reach the wrapped writer", err)
+		}
+	}))
+
+	rec := &deadlineRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/events", nil))
+	if !rec.Flushed {
+		t.Errorf("Flushed = false, want the Flush passed through to the wrapped writer")
+	}
+	if !rec.deadline.Equal(deadline) {
+		t.Errorf("write deadline = %v, want %v", rec.deadline, deadline)
+	}
+}
+
+func TestLevelHandler(t *testing.T) {
+	logger := New(&bytes.Buffer{}, INFO)
+	handler := logger.LevelHandler()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/loglevel", nil))
+	if rec.Code != http.StatusOK || strings.TrimSpace(rec.Body.String()) != `{"level":"INFO"}` {
+		t.Errorf("GET = %d %q, want 200 {\"level\":\"INFO\"}", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/debug/loglevel", strings.NewReader("debug\n")))
+	if rec.Code != http.StatusOK || logger.GetLogLevel() != DEBUG {
+		t.Errorf("PUT debug = %d %q, level %v, want 200 and DEBUG", rec.Code, rec.Body.String(), logger.GetLogLevel())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/loglevel", strings.NewReader("LOUD")))
+	if rec.Code != http.StatusBadRequest || logger.GetLogLevel() != DEBUG {
+		t.Errorf("POST LOUD = %d, level %v, want 400 and DEBUG kept", rec.Code, logger.GetLogLevel())
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/debug/loglevel", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE = %d, want 405", rec.Code)
+	}
+}
@@ -0,0 +1,132 @@
+package alog
+
+import "fmt"
+
+// The KV functions take their args as alternating keys and values, which are attached to the record as structured fields,
+// as a shorthand for WithFields :
+//
+//	logger.InfoKV("user login", "user", id, "ip", addr)
+//
+// The message is written verbatim. A key which is not a string is formatted with fmt.Sprint, and a key without a value
+// is given the value "(MISSING)".
+
+// kvMissing is the value of a trailing key which has no value
+const kvMissing = "(MISSING)"
+
+// kvFields returns the alternating keys and values in kvs as Fields
+func kvFields(kvs []interface{}) Fields {
+	if len(kvs) == 0 {
+		return nil
+	}
+	fields := make(Fields, (len(kvs)+1)/2)
+	for i := 0; i < len(kvs); i += 2 {
+		key, ok := kvs[i].(string)
+		if !ok {
+			key = fmt.Sprint(kvs[i])
+		}
+		if i+1 < len(kvs) {
+			fields[key] = kvs[i+1]
+		} else {
+			fields[key] = kvMissing
+		}
+	}
+	return fields
+}
+
+func (l *Logger) TraceKV(msg string, kvs ...interface{}) {
+	var level LogLevel = TRACE
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, kvFields(kvs), msg)
+	}
+}
+
+func (l *Logger) DebugKV(msg string, kvs ...interface{}) {
+	var level LogLevel = DEBUG
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, kvFields(kvs), msg)
+	}
+}
+
+func (l *Logger) InfoKV(msg string, kvs ...interface{}) {
+	var level LogLevel = INFO
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, kvFields(kvs), msg)
+	}
+}
+
+func (l *Logger) NoticeKV(msg string, kvs ...interface{}) {
+	var level LogLevel = NOTICE
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, kvFields(kvs), msg)
+	}
+}
+
+func (l *Logger) WarnKV(msg string, kvs ...interface{}) {
+	var level LogLevel = WARN
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, kvFields(kvs), msg)
+	}
+}
+
+func (l *Logger) ErrorKV(msg string, kvs ...interface{}) {
+	var level LogLevel = ERROR
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, kvFields(kvs), msg)
+	}
+}
+
+func (l *Logger) CriticalKV(msg string, kvs ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, kvFields(kvs), msg)
+	}
+}
+
+func TraceKV(msg string, kvs ...interface{}) {
+	var level LogLevel = TRACE
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, kvFields(kvs), msg)
+	}
+}
+
+func DebugKV(msg string, kvs ...interface{}) {
+	var level LogLevel = DEBUG
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, kvFields(kvs), msg)
+	}
+}
+
+func InfoKV(msg string, kvs ...interface{}) {
+	var level LogLevel = INFO
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, kvFields(kvs), msg)
+	}
+}
+
+func NoticeKV(msg string, kvs ...interface{}) {
+	var level LogLevel = NOTICE
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, kvFields(kvs), msg)
+	}
+}
+
+func WarnKV(msg string, kvs ...interface{}) {
+	var level LogLevel = WARN
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, kvFields(kvs), msg)
+	}
+}
+
+func ErrorKV(msg string, kvs ...interface{}) {
+	var level LogLevel = ERROR
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, kvFields(kvs), msg)
+	}
+}
+
+func CriticalKV(msg string, kvs ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, kvFields(kvs), msg)
+	}
+}
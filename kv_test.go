@@ -0,0 +1,36 @@
+package alog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestKV(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	logger.InfoKV("user login", "user", "alice", "ip", "10.0.0.1", 7, true)
+	logger.WarnKV("100% odd", "user", "bob", "attempt")
+	logger.ErrorKV("no fields")
+	logger.DebugKV("suppressed", "user", "carol")
+
+	want := "- [INFO] - user login 7=true ip=10.0.0.1 user=alice\n" +
+		"- [WARN] - 100% odd attempt=(MISSING) user=bob\n" +
+		"- [ERROR] - no fields\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestKVLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFormat(FormatLogfmt)
+	logger.SetTimestamp(false)
+
+	logger.InfoKV("request", "status", 200, "path", "/")
+	if got, want := buf.String(), "level=info msg=request path=/ status=200\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package alog
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SetLevelLabels replaces the level tags written in text records, e.g. map[LogLevel]string{INFO: "I", ...}.
+// labels must hold a non-empty tag for every level from TRACE to CRITICAL, and nothing else.
+// Spaces around a tag are dropped, since the record layout puts exactly one space on either side of it.
+func (l *Logger) SetLevelLabels(labels map[LogLevel]string) error {
+	custom := make(map[LogLevel]string, len(logLevelIntToStringMap))
+	for level := TRACE; level <= CRITICAL; level++ {
+		label := strings.TrimSpace(labels[level])
+		if len(label) == 0 {
+			return fmt.Errorf("alog: no label specified for level %v", level)
+		}
+		custom[level] = label
+	}
+	for level := range labels {
+		if level > CRITICAL {
+			return fmt.Errorf("alog: label specified for %v, which is not a logging level", level)
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.labels = custom
+	return nil
+}
+
+// SetLevelLabels replaces the level tags written in text records by the default Logger
+func SetLevelLabels(labels map[LogLevel]string) error {
+	return std.SetLevelLabels(labels)
+}
+
+// SetBracketedLevels turns on or off the brackets around the default level tags of text records, e.g. "INFO" instead of
+// "[INFO]", for parsers which do not expect them. They are on by default. Tags set with SetLevelLabels are written as given,
+// whatever this setting.
+func (l *Logger) SetBracketedLevels(bracketed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bareLevels = !bracketed
+}
+
+// SetBracketedLevels turns on or off the brackets around the default level tags of the default Logger
+func SetBracketedLevels(bracketed bool) {
+	std.SetBracketedLevels(bracketed)
+}
@@ -0,0 +1,89 @@
+package alog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSetLevelLabels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	err := logger.SetLevelLabels(map[LogLevel]string{
+		TRACE: "T", DEBUG: "D", INFO: "I", NOTICE: "N", WARN: "W", ERROR: "E", CRITICAL: "C",
+	})
+	if err != nil {
+		t.Fatalf("SetLevelLabels returned %v", err)
+	}
+
+	logger.Warn("disk nearly full")
+
+	if got, want := buf.String(), "- W - disk nearly full\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestSetLevelLabelsColored(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+	logger.SetColor(ColorAlways)
+	logger.SetLevelLabels(map[LogLevel]string{
+		TRACE: "trace", DEBUG: "debug", INFO: "info", NOTICE: "notice", WARN: "warn", ERROR: "error", CRITICAL: "critical",
+	})
+
+	logger.Error("failed")
+
+	if got, want := buf.String(), "- \x1b[31merror\x1b[0m - failed\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestSetLevelLabelsIncomplete(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	if err := logger.SetLevelLabels(map[LogLevel]string{INFO: "I"}); err == nil {
+		t.Error("SetLevelLabels with missing levels returned nil error")
+	}
+	if err := logger.SetLevelLabels(map[LogLevel]string{
+		TRACE: "T", DEBUG: "D", INFO: "I", NOTICE: "N", WARN: "W", ERROR: "E", CRITICAL: "C", OFF: "O",
+	}); err == nil {
+		t.Error("SetLevelLabels with OFF returned nil error")
+	}
+
+	logger.Info("unchanged")
+
+	if got, want := buf.String(), "- [INFO] - unchanged\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestSetBracketedLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	logger.SetBracketedLevels(false)
+	logger.Info("bare")
+	logger.SetColor(ColorAlways)
+	logger.Error("bare colored")
+	logger.SetColor(ColorNever)
+	logger.SetBracketedLevels(true)
+	logger.Info("bracketed again")
+	logger.SetBracketedLevels(false)
+	logger.SetLevelLabels(map[LogLevel]string{
+		TRACE: "<T>", DEBUG: "<D>", INFO: "<I>", NOTICE: "<N>", WARN: "<W>", ERROR: "<E>", CRITICAL: "<C>",
+	})
+	logger.Warn("custom as given")
+
+	want := "- INFO - bare\n" +
+		"- \x1b[31mERROR\x1b[0m - bare colored\n" +
+		"- [INFO] - bracketed again\n" +
+		"- <W> - custom as given\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,132 @@
+package alog
+
+import "io"
+
+// destination is a writer added with AddDestination, which only receives the records at or above its minLevel
+type destination struct {
+	w        io.Writer
+	minLevel LogLevel
+}
+
+// levelRouter is implemented by the writers which route each record by its level, i.e. fanOut and the async writer in front of it
+type levelRouter interface {
+	writeLevel(level LogLevel, p []byte) error
+}
+
+// fanOut writes each record to the main destination, to the added destinations whose threshold it meets and to syslog.
+// Unlike io.MultiWriter, a failing writer does not prevent the write to the remaining writers.
+type fanOut struct {
+	out    io.Writer
+	extra  []destination
+	syslog levelledWriter
+}
+
+// Write writes p to all the writers, regardless of their threshold
+func (f fanOut) Write(p []byte) (int, error) {
+	return len(p), f.writeLevel(CRITICAL, p)
+}
+
+// writeLevel writes a record at level to the main destination, to the added destinations whose threshold it meets and to
+// syslog, at the priority of level. It returns the first error, if any.
+func (f fanOut) writeLevel(level LogLevel, p []byte) error {
+	_, err := f.out.Write(p)
+	for _, d := range f.extra {
+		if level < d.minLevel {
+			continue
+		}
+		if _, werr := d.w.Write(p); werr != nil && err == nil {
+			err = werr
+		}
+	}
+	if f.syslog != nil {
+		if serr := f.syslog.writeLevel(level, p); serr != nil && err == nil {
+			err = serr
+		}
+	}
+	return err
+}
+
+// Sync flushes all the writers which support it
+func (f fanOut) Sync() error {
+	var err error
+	ws := []io.Writer{f.out}
+	for _, d := range f.extra {
+		ws = append(ws, d.w)
+	}
+	for _, w := range ws {
+		if s, ok := w.(syncer); ok {
+			if serr := s.Sync(); serr != nil && err == nil {
+				err = serr
+			}
+		}
+	}
+	return err
+}
+
+// routeRecord writes a record at level to w, routing it by level if w supports it
+func routeRecord(w io.Writer, level LogLevel, p []byte) error {
+	if r, ok := w.(levelRouter); ok {
+		return r.writeLevel(level, p)
+	}
+	_, err := w.Write(p)
+	return err
+}
+
+// output returns a writer for the current destinations. l.mu must be held.
+func (l *Logger) output() io.Writer {
+	if len(l.extra) == 0 && l.syslog == nil {
+		return l.out
+	}
+	return fanOut{out: l.out, extra: l.extra, syslog: l.syslog}
+}
+
+// updateOutput points the Logger at the current destinations, through the async writer if enabled. l.mu must be held.
+func (l *Logger) updateOutput() {
+	l.colorOn = l.useColor()
+	if l.async != nil {
+		l.async.setOutput(l.output())
+		l.w = l.async
+		return
+	}
+	l.w = l.output()
+}
+
+// AddDestination adds w to the destinations of the Logger, so that every record at minLevel or above is also written to w,
+// e.g. AddDestination(alertFile, ERROR) next to a main destination which takes everything the Logger logs.
+// The threshold only narrows what w receives: records suppressed by the log level of the Logger are not written anywhere.
+// The added destinations are kept across SetLogDestination, SetFormat and SetFlags calls.
+func (l *Logger) AddDestination(w io.Writer, minLevel LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.extra = append(l.extra, destination{w: w, minLevel: minLevel})
+	l.updateOutput()
+}
+
+// SetDestinations replaces all the destinations of the Logger with ws, each of which receives every record.
+// The first writer becomes the main destination. With no writers, records are discarded.
+// Like SetLogDestination, this takes precedence over the fileName in alog.conf.
+func (l *Logger) SetDestinations(ws ...io.Writer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.extra = nil
+	if len(ws) == 0 {
+		l.out = io.Discard
+	} else {
+		l.out = ws[0]
+		for _, w := range ws[1:] {
+			l.extra = append(l.extra, destination{w: w, minLevel: TRACE})
+		}
+	}
+	l.destinationSet = true
+	l.updateOutput()
+}
+
+// AddDestination adds w to the destinations of the default Logger, for the records at minLevel or above
+func AddDestination(w io.Writer, minLevel LogLevel) {
+	std.AddDestination(w, minLevel)
+}
+
+// SetDestinations replaces all the destinations of the default Logger with ws
+func SetDestinations(ws ...io.Writer) {
+	std.SetDestinations(ws...)
+}
@@ -0,0 +1,93 @@
+package alog
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestAddDestination(t *testing.T) {
+	var first, second bytes.Buffer
+	logger := New(failingWriter{}, INFO)
+	logger.SetLogDestination(&first)
+	logger.AddDestination(failingWriter{}, TRACE)
+	logger.AddDestination(&second, TRACE)
+	logger.SetFormat(FormatText)
+	logger.SetFlags(0)
+
+	logger.Info("fanned out")
+
+	if first.String() != "- [INFO] - fanned out\n" || first.String() != second.String() {
+		t.Errorf("destinations received %q and %q, want the same line", first.String(), second.String())
+	}
+}
+
+func TestSetDestinations(t *testing.T) {
+	var first, second bytes.Buffer
+	logger := New(&bytes.Buffer{}, INFO)
+	logger.AddDestination(&bytes.Buffer{}, TRACE)
+	logger.SetDestinations(&first, &second)
+
+	logger.Warn("replaced")
+
+	if !strings.Contains(first.String(), "replaced") || !strings.Contains(second.String(), "replaced") {
+		t.Errorf("destinations received %q and %q, want both to contain the line", first.String(), second.String())
+	}
+}
+
+func TestAddDestinationMinLevel(t *testing.T) {
+	var main, warnings, errs bytes.Buffer
+	logger := New(&main, DEBUG)
+	logger.AddDestination(&warnings, WARN)
+	logger.AddDestination(&errs, ERROR)
+	logger.SetFlags(0)
+
+	logger.Trace("suppressed")
+	logger.Info("routine")
+	logger.Warn("suspicious")
+	logger.Error("broken")
+
+	want := map[*bytes.Buffer][]string{
+		&main:     {"routine", "suspicious", "broken"},
+		&warnings: {"suspicious", "broken"},
+		&errs:     {"broken"},
+	}
+	for buf, msgs := range want {
+		if lines := strings.Count(buf.String(), "\n"); lines != len(msgs) {
+			t.Errorf("destination received %d records, want %d: %q", lines, len(msgs), buf.String())
+		}
+		for _, msg := range msgs {
+			if !strings.Contains(buf.String(), msg) {
+				t.Errorf("destination received %q, want it to contain %q", buf.String(), msg)
+			}
+		}
+	}
+	if strings.Contains(main.String(), "suppressed") {
+		t.Errorf("main destination received %q, want the TRACE record suppressed", main.String())
+	}
+}
+
+func TestAddDestinationMinLevelAsync(t *testing.T) {
+	var main, errs bytes.Buffer
+	logger := New(&main, INFO)
+	logger.AddDestination(&errs, ERROR)
+	logger.EnableAsync(16)
+
+	logger.Info("routine")
+	logger.Error("broken")
+	logger.DisableAsync()
+
+	if !strings.Contains(main.String(), "routine") || !strings.Contains(main.String(), "broken") {
+		t.Errorf("main destination received %q, want both records", main.String())
+	}
+	if strings.Contains(errs.String(), "routine") || !strings.Contains(errs.String(), "broken") {
+		t.Errorf("ERROR destination received %q, want only the ERROR record", errs.String())
+	}
+}
@@ -0,0 +1,42 @@
+package alog
+
+import "time"
+
+// noOperation is returned by TimeOperation when its level is suppressed, so that nothing is timed or logged
+func noOperation() {}
+
+// TimeOperation logs "<name> started" at level and returns a function which logs "<name> completed in <elapsed>",
+// to be deferred at the start of the operation :
+//
+//	defer logger.TimeOperation(alog.DEBUG, "cache warm up")()
+//
+// If level is suppressed when TimeOperation is called, neither line is logged and the operation is not timed.
+func (l *Logger) TimeOperation(level LogLevel, name string) func() {
+	logFunc := l.logFunc(level)
+	if logFunc == nil || !l.IsEnabled(level) {
+		return noOperation
+	}
+	logFunc(l, level, nil, "%s started", name)
+	start := time.Now()
+	return func() {
+		if logFunc := l.logFunc(level); logFunc != nil {
+			logFunc(l, level, nil, "%s completed in %v", name, time.Since(start))
+		}
+	}
+}
+
+// TimeOperation logs the start of an operation through the default Logger and returns a function which logs its completion,
+// see (*Logger).TimeOperation
+func TimeOperation(level LogLevel, name string) func() {
+	logFunc := std.logFunc(level)
+	if logFunc == nil || !std.IsEnabled(level) {
+		return noOperation
+	}
+	logFunc(std, level, nil, "%s started", name)
+	start := time.Now()
+	return func() {
+		if logFunc := std.logFunc(level); logFunc != nil {
+			logFunc(std, level, nil, "%s completed in %v", name, time.Since(start))
+		}
+	}
+}
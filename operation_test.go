@@ -0,0 +1,39 @@
+package alog
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestTimeOperation(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	done := logger.TimeOperation(INFO, "cache warm up")
+	time.Sleep(2 * time.Millisecond)
+	done()
+
+	want := regexp.MustCompile(`^- \[INFO\] - cache warm up started\n- \[INFO\] - cache warm up completed in [0-9.]+(µs|ms|s)\n$`)
+	if got := buf.String(); !want.MatchString(got) {
+		t.Errorf("output = %q, want it to match %v", got, want)
+	}
+}
+
+func TestTimeOperationSuppressed(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.EnableRingBuffer(4)
+
+	logger.TimeOperation(DEBUG, "not timed")()
+	if buf.Len() != 0 {
+		t.Errorf("output = %q, want nothing for a suppressed level", buf.String())
+	}
+	var dump bytes.Buffer
+	logger.DumpRingBuffer(&dump)
+	if dump.Len() != 0 {
+		t.Errorf("ring buffer = %q, want nothing for a suppressed level", dump.String())
+	}
+}
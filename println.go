@@ -0,0 +1,152 @@
+package alog
+
+import "unsafe"
+
+// The Ln functions log their args as fmt.Println does, with spaces between them, rather than as a format string,
+// so that a % in a message is always written verbatim. LogBytes does the same for a message held in a byte slice.
+
+func (l *Logger) TraceLn(args ...interface{}) {
+	var level LogLevel = TRACE
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, sprintln(args...))
+	}
+}
+
+func (l *Logger) DebugLn(args ...interface{}) {
+	var level LogLevel = DEBUG
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, sprintln(args...))
+	}
+}
+
+func (l *Logger) InfoLn(args ...interface{}) {
+	var level LogLevel = INFO
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, sprintln(args...))
+	}
+}
+
+func (l *Logger) NoticeLn(args ...interface{}) {
+	var level LogLevel = NOTICE
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, sprintln(args...))
+	}
+}
+
+func (l *Logger) WarnLn(args ...interface{}) {
+	var level LogLevel = WARN
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, sprintln(args...))
+	}
+}
+
+func (l *Logger) ErrorLn(args ...interface{}) {
+	var level LogLevel = ERROR
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, sprintln(args...))
+	}
+}
+
+func (l *Logger) CriticalLn(args ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, sprintln(args...))
+	}
+}
+
+func TraceLn(args ...interface{}) {
+	var level LogLevel = TRACE
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, sprintln(args...))
+	}
+}
+
+func DebugLn(args ...interface{}) {
+	var level LogLevel = DEBUG
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, sprintln(args...))
+	}
+}
+
+func InfoLn(args ...interface{}) {
+	var level LogLevel = INFO
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, sprintln(args...))
+	}
+}
+
+func NoticeLn(args ...interface{}) {
+	var level LogLevel = NOTICE
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, sprintln(args...))
+	}
+}
+
+func WarnLn(args ...interface{}) {
+	var level LogLevel = WARN
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, sprintln(args...))
+	}
+}
+
+func ErrorLn(args ...interface{}) {
+	var level LogLevel = ERROR
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, sprintln(args...))
+	}
+}
+
+func CriticalLn(args ...interface{}) {
+	var level LogLevel = CRITICAL
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, sprintln(args...))
+	}
+}
+
+// LogBytes logs b verbatim as the message at level, without any format processing, for callers which already
+// hold the message as a byte slice, such as the output of an encoder. b can be reused once LogBytes returns.
+func (l *Logger) LogBytes(level LogLevel, b []byte) {
+	if logFunc := l.logFunc(level); logFunc != nil {
+		if !l.IsEnabled(level) {
+			// The record only goes to the ring buffer
+			logFunc(l, level, nil, string(b))
+			return
+		}
+		l.logBytes(level, b)
+	}
+}
+
+// LogBytes logs b verbatim as the message at level through the default Logger
+func LogBytes(level LogLevel, b []byte) {
+	if logFunc := std.logFunc(level); logFunc != nil {
+		if !std.IsEnabled(level) {
+			logFunc(std, level, nil, string(b))
+			return
+		}
+		std.logBytes(level, b)
+	}
+}
+
+// logBytes logs b as logMsg logs a message without args. Unless deduplication or hooks, which keep the message after the
+// record is written, are on, the message shares the memory of b rather than being copied into a string.
+func (l *Logger) logBytes(level LogLevel, b []byte) {
+	s := l.settings(level)
+	if !s.sampled() {
+		return
+	}
+
+	var callSite string
+	if s.callerInfo {
+		callSite = caller()
+	}
+	var fields Fields
+	if s.stackTrace {
+		fields = fields.with(stackField, stack())
+	}
+
+	msg := unsafe.String(unsafe.SliceData(b), len(b))
+	if s.dedup != nil || len(s.hooks) != 0 {
+		msg = string(b)
+	}
+	l.write(s, level, fields, callSite, msg, l.writeRecord)
+}
@@ -0,0 +1,99 @@
+package alog
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLnIsVerbatim(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	logger.InfoLn("100% done {}")
+	logger.WarnLn("retry", 3, "of", 5, "%d")
+	logger.ErrorLn()
+
+	want := "- [INFO] - 100% done {}\n" +
+		"- [WARN] - retry 3 of 5 %d\n" +
+		"- [ERROR] - \n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestLnSuppressed(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, ERROR)
+
+	logger.DebugLn("not written")
+	if buf.Len() != 0 {
+		t.Errorf("output = %q, want nothing below ERROR", buf.String())
+	}
+}
+
+func TestLogBytes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	b := []byte(`{"progress":"100%d"}`)
+	logger.LogBytes(WARN, b)
+	b[0] = 'X'
+	logger.LogBytes(DEBUG, b)
+
+	if got, want := buf.String(), `- [WARN] - {"progress":"100%d"}`+"\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestLogBytesReused(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, DEBUG)
+	logger.SetFlags(0)
+	logger.SetDedup(time.Hour)
+	logger.EnableRingBuffer(4)
+	var hooked []string
+	logger.AddHook(TRACE, func(level LogLevel, msg string) { hooked = append(hooked, msg) })
+
+	b := []byte("original")
+	logger.LogBytes(INFO, b)
+	logger.LogBytes(INFO, b)
+	logger.LogBytes(TRACE, b)
+	copy(b, "reused!!")
+	logger.SetDedup(0)
+
+	want := "- [INFO] - original\n- [INFO] - original (repeated 1 times)\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if len(hooked) != 1 || hooked[0] != "original" {
+		t.Errorf("messages passed to the hook = %q, want [original]", hooked)
+	}
+	var dump bytes.Buffer
+	logger.DumpRingBuffer(&dump)
+	if got := dump.String(); !strings.Contains(got, "[TRACE] - original") {
+		t.Errorf("DumpRingBuffer() = %q, want the TRACE record", got)
+	}
+}
+
+func BenchmarkLogBytes(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	msg := []byte(`{"user":"alice","action":"login","ok":true}`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.LogBytes(INFO, msg)
+	}
+}
+
+func BenchmarkInfoFormattedBytes(b *testing.B) {
+	logger := New(io.Discard, INFO)
+	msg := []byte(`{"user":"alice","action":"login","ok":true}`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.Infof("%s", msg)
+	}
+}
@@ -0,0 +1,73 @@
+package alog
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+)
+
+// pid and hostname are looked up once, at startup, for SetIncludePID and SetIncludeHostname
+var (
+	pid      = os.Getpid()
+	hostname = lookupHostname()
+)
+
+// lookupHostname returns the hostname reported by the kernel, or "unknown" if it cannot be determined
+func lookupHostname() string {
+	name, err := os.Hostname()
+	if err != nil || len(name) == 0 {
+		return "unknown"
+	}
+	return name
+}
+
+// withProcessFields returns fields with the "pid" and "host" fields added as requested
+func withProcessFields(fields Fields, includePID, includeHost bool) Fields {
+	if includePID {
+		fields = fields.with("pid", pid)
+	}
+	if includeHost {
+		fields = fields.with("host", hostname)
+	}
+	return fields
+}
+
+// writeProcessTokens appends the "[pid=N] " and "[host=name] " tokens of a text record as requested
+func writeProcessTokens(buf *bytes.Buffer, includePID, includeHost bool) {
+	if includePID {
+		buf.WriteString("[pid=")
+		buf.WriteString(strconv.Itoa(pid))
+		buf.WriteString("] ")
+	}
+	if includeHost {
+		buf.WriteString("[host=")
+		buf.WriteString(hostname)
+		buf.WriteString("] ")
+	}
+}
+
+// SetIncludePID turns on or off the process ID in every record of the Logger,
+// as a "[pid=N]" token in text records and a "pid" field in JSON and logfmt records
+func (l *Logger) SetIncludePID(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.includePID = enabled
+}
+
+// SetIncludePID turns on or off the process ID in the records of the default Logger
+func SetIncludePID(enabled bool) {
+	std.SetIncludePID(enabled)
+}
+
+// SetIncludeHostname turns on or off the hostname in every record of the Logger,
+// as a "[host=name]" token in text records and a "host" field in JSON and logfmt records
+func (l *Logger) SetIncludeHostname(enabled bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.includeHost = enabled
+}
+
+// SetIncludeHostname turns on or off the hostname in the records of the default Logger
+func SetIncludeHostname(enabled bool) {
+	std.SetIncludeHostname(enabled)
+}
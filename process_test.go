@@ -0,0 +1,39 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestIncludePIDAndHostname(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetIncludePID(true)
+	logger.SetIncludeHostname(true)
+
+	logger.Info("tagged")
+
+	host, _ := os.Hostname()
+	if got, want := buf.String(), fmt.Sprintf("[pid=%d] [host=%s] - [INFO] - tagged\n", os.Getpid(), host); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	logger.SetFormat(FormatJSON)
+	logger.Info("tagged")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("output %q is not JSON: %v", buf.String(), err)
+	}
+	if got, ok := rec["pid"].(float64); !ok || int(got) != os.Getpid() {
+		t.Errorf("pid = %v, want %d", rec["pid"], os.Getpid())
+	}
+	if rec["host"] != host {
+		t.Errorf("host = %v, want %q", rec["host"], host)
+	}
+}
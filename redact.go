@@ -0,0 +1,37 @@
+package alog
+
+import "regexp"
+
+// redaction replaces the matches of pattern in messages with replacement
+type redaction struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// redact returns msg with the redactions applied in order
+func redact(redactions []redaction, msg string) string {
+	for _, r := range redactions {
+		msg = r.pattern.ReplaceAllString(msg, r.replacement)
+	}
+	return msg
+}
+
+// AddRedaction makes the Logger replace the substrings of every message which match pattern with replacement,
+// before the record is written, in text as well as JSON and logfmt records, and before it is passed to hooks.
+// replacement may refer to submatches as in regexp.ReplaceAllString, e.g.
+//
+//	logger.AddRedaction(regexp.MustCompile(`(password=)\S+`), "${1}***")
+//
+// Redactions are applied in the order they were added. Field values are not redacted.
+func (l *Logger) AddRedaction(pattern *regexp.Regexp, replacement string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	redactions := make([]redaction, len(l.redactions), len(l.redactions)+1)
+	copy(redactions, l.redactions)
+	l.redactions = append(redactions, redaction{pattern: pattern, replacement: replacement})
+}
+
+// AddRedaction makes the default Logger replace the substrings of every message which match pattern with replacement
+func AddRedaction(pattern *regexp.Regexp, replacement string) {
+	std.AddRedaction(pattern, replacement)
+}
@@ -0,0 +1,39 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"testing"
+)
+
+func TestAddRedaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.AddRedaction(regexp.MustCompile(`(password=)\S+`), "${1}***")
+	logger.AddRedaction(regexp.MustCompile(`Bearer [A-Za-z0-9.]+`), "Bearer [REDACTED]")
+
+	logger.Infof("login user=alice password=hunter2 header=%q", "Bearer abc.def")
+
+	if got, want := buf.String(), `- [INFO] - login user=alice password=*** header="Bearer [REDACTED]"`+"\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestAddRedactionJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFormat(FormatJSON)
+	logger.AddRedaction(regexp.MustCompile(`\d{4}-\d{4}-\d{4}-(\d{4})`), "****-****-****-$1")
+
+	logger.Warn("charging card 1234-5678-9012-3456")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("output %q is not JSON: %v", buf.String(), err)
+	}
+	if got, want := rec["msg"], "charging card ****-****-****-3456"; got != want {
+		t.Errorf("msg = %q, want %q", got, want)
+	}
+}
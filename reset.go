@@ -0,0 +1,43 @@
+package alog
+
+import (
+	"os"
+	"time"
+)
+
+// Reset restores the default Logger to its state at startup before alog.conf is applied : level TRACE, written to stdout
+// with DefaultFlags, in the text format and with no hooks, redactions or added destinations.
+// It stops the config watcher and the SIGHUP handler, writes out the queued async records and closes the log file and
+// the syslog connection, as Close does. The config file is not read again; use ReloadConfig for that.
+// Loggers returned by Named share the default Logger's settings, so they are reset too.
+func Reset() {
+	StopWatch()
+	StopSIGHUP()
+	std.SetDedup(0)
+	std.Close()
+
+	std.mu.Lock()
+	std.out, std.extra, std.destinationSet = os.Stdout, nil, false
+	std.format, std.formatter = FormatText, nil
+	std.colorMode, std.labels, std.bareLevels = ColorNever, nil, false
+	std.prefix, std.separator = "", defaultSeparator
+	std.includePID, std.includeHost, std.callerInfo, std.errorChain = false, false, false, false
+	std.stackLevel = OFF
+	std.fileName, std.fileOpts = "", FileOptions{}
+	std.overflow = Block
+	std.sampling = nil
+	std.hooks, std.redactions = nil, nil
+	std.ring = nil
+	std.flags, std.timeFormat, std.clock, std.noTimestamp = DefaultFlags, "", time.Now, false
+	std.lineEnding = "\n"
+	std.setLogLevel(defaultLogLevel)
+	std.disabledLevel = nil
+	std.updateOutput()
+	std.mu.Unlock()
+
+	std.fallback.mu.Lock()
+	std.fallback.warned, std.fallback.start, std.fallback.count, std.fallback.dropped = false, time.Time{}, 0, 0
+	std.fallback.mu.Unlock()
+
+	std.ResetStats()
+}
@@ -0,0 +1,51 @@
+//go:build !alog_prod
+
+package alog
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestReset(t *testing.T) {
+	restoreDefaultLogger(t)
+
+	var buf bytes.Buffer
+	SetLogDestination(&buf)
+	SetLogLevel(ERROR)
+	SetFlags(0)
+	SetPrefix("app: ")
+	SetFormat(FormatJSON)
+	hooked := 0
+	AddHook(TRACE, func(level LogLevel, msg string) { hooked++ })
+	AddRedaction(regexp.MustCompile(`secret`), "***")
+	Error("before reset")
+
+	Reset()
+
+	if got := GetLogLevel(); got != defaultLogLevel {
+		t.Errorf("level after Reset = %v, want %v", got, defaultLogLevel)
+	}
+	if got := GetLogDestination(); got != os.Stdout {
+		t.Errorf("destination after Reset = %v, want os.Stdout", got)
+	}
+	if got := GetFlags(); got != DefaultFlags {
+		t.Errorf("flags after Reset = %d, want DefaultFlags", got)
+	}
+	if got := Stats()[ERROR]; got != 0 {
+		t.Errorf("ERROR count after Reset = %d, want 0", got)
+	}
+
+	buf.Reset()
+	SetLogDestination(&buf)
+	SetFlags(0)
+	Trace("my secret")
+	if got, want := buf.String(), "- [TRACE] - my secret\n"; got != want {
+		t.Errorf("record after Reset = %q, want %q", got, want)
+	}
+	if hooked != 1 {
+		t.Errorf("hook calls = %d, want 1, from before Reset only", hooked)
+	}
+}
@@ -0,0 +1,99 @@
+package alog
+
+import (
+	"io"
+	"sync"
+)
+
+// ringBuffer keeps copies of the last len(records) formatted records, overwriting the oldest one once it is full
+type ringBuffer struct {
+	mu      sync.Mutex
+	records [][]byte
+	// next is the slot the next record is written to, which holds the oldest record once full is set
+	next int
+	full bool
+}
+
+func newRingBuffer(n int) *ringBuffer {
+	return &ringBuffer{records: make([][]byte, n)}
+}
+
+// write copies b into the ring, reusing the slot of the record it replaces
+func (r *ringBuffer) write(level LogLevel, b []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[r.next] = append(r.records[r.next][:0], b...)
+	r.next++
+	if r.next == len(r.records) {
+		r.next, r.full = 0, true
+	}
+}
+
+// dump writes the records held by the ring to w, oldest first
+func (r *ringBuffer) dump(w io.Writer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	start, n := 0, r.next
+	if r.full {
+		start, n = r.next, len(r.records)
+	}
+	for i := 0; i < n; i++ {
+		if _, err := w.Write(r.records[(start+i)%len(r.records)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ringMsg is the function value for the levels below the threshold while the ring buffer is on.
+// The message is formatted as for the destination, but only recorded in the ring buffer, without being counted or passed to hooks.
+func (l *Logger) ringMsg(level LogLevel, fields Fields, msg string, objs ...interface{}) {
+	s := l.settings(level)
+	var callSite string
+	if s.callerInfo {
+		callSite = caller()
+	}
+
+	l.mu.RLock()
+	ring := l.ring
+	l.mu.RUnlock()
+
+	if ring != nil {
+		l.render(s, level, fields, callSite, redact(s.redactions, expandMsg(msg, objs...)), ring.write)
+	}
+}
+
+// EnableRingBuffer makes the Logger keep its last n records in memory, for DumpRingBuffer to write out, e.g. from a panic handler.
+// Records at every level are kept, including those below the level of the Logger, which are not written to its destinations.
+// Calling EnableRingBuffer again replaces the buffer, and an n of 0 or less turns it off.
+func (l *Logger) EnableRingBuffer(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.ring = nil
+	if n > 0 {
+		l.ring = newRingBuffer(n)
+	}
+	l.setLogLevel(l.level)
+}
+
+// DumpRingBuffer writes the records kept by EnableRingBuffer to w, oldest first. It writes nothing if the ring buffer is off.
+func (l *Logger) DumpRingBuffer(w io.Writer) error {
+	l.mu.RLock()
+	ring := l.ring
+	l.mu.RUnlock()
+
+	if ring == nil {
+		return nil
+	}
+	return ring.dump(w)
+}
+
+// EnableRingBuffer makes the default Logger keep its last n records in memory, see (*Logger).EnableRingBuffer
+func EnableRingBuffer(n int) {
+	std.EnableRingBuffer(n)
+}
+
+// DumpRingBuffer writes the records kept by the default Logger to w, oldest first
+func DumpRingBuffer(w io.Writer) error {
+	return std.DumpRingBuffer(w)
+}
@@ -0,0 +1,19 @@
+package alog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRingBufferNotFull(t *testing.T) {
+	logger := New(&bytes.Buffer{}, OFF)
+	logger.SetFlags(0)
+	logger.EnableRingBuffer(4)
+	logger.Warnf("only %d", 1)
+
+	var dump bytes.Buffer
+	logger.DumpRingBuffer(&dump)
+	if got, want := dump.String(), "- [WARN] - only 1\n"; got != want {
+		t.Errorf("DumpRingBuffer() = %q, want %q", got, want)
+	}
+}
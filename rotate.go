@@ -0,0 +1,284 @@
+package alog
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rotation is the type used to specify when a log file is rolled over to a new file
+type Rotation uint8
+
+// The rotation constants specify the rotation policies which can be configured
+const (
+	// RotateNone keeps writing to the same file
+	RotateNone Rotation = iota
+	// RotateDaily rolls over at midnight into date-stamped files, e.g. app-2024-06-01.log for app.log
+	RotateDaily
+)
+
+var rotationStringToIntMap = map[string]Rotation{
+	"none":  RotateNone,
+	"daily": RotateDaily,
+}
+
+// ParseRotation returns the Rotation named by s, e.g. "daily". The match is case-insensitive.
+func ParseRotation(s string) (Rotation, error) {
+	rotation, ok := rotationStringToIntMap[strings.ToLower(s)]
+	if !ok {
+		return rotation, fmt.Errorf("Invalid Rotation : %s. Valid Values are none|daily", s)
+	}
+	return rotation, nil
+}
+
+// dayLayout is the layout of the date stamp in the names of daily rotated files
+const dayLayout = "2006-01-02"
+
+// rotatingFile is an io.Writer which writes to a log file and rolls it over according to its rotation policy and size limit.
+// The policy is checked on every write, under a mutex, so that concurrent writers never see a half rotated file.
+type rotatingFile struct {
+	mu       sync.Mutex
+	fileName string
+	FileOptions
+	now func() time.Time
+	// day is the date stamp of the open file
+	day  string
+	file *os.File
+	// size is the number of bytes in the open file
+	size int64
+	// compressing tracks the background compression of rotated out files
+	compressing sync.WaitGroup
+}
+
+// openRotatingFile opens the log file for fileName according to the rotation options
+func openRotatingFile(fileName string, opts FileOptions) (*rotatingFile, error) {
+	r := &rotatingFile{fileName: fileName, FileOptions: opts, now: time.Now}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	// Truncate only applies to the file opened at startup, not to the files rotated into
+	r.Truncate = false
+	return r, nil
+}
+
+// currentFileName returns the name of the file which should currently be written, along with its date stamp
+func (r *rotatingFile) currentFileName() (string, string) {
+	if r.Rotation != RotateDaily {
+		return r.fileName, ""
+	}
+	day := r.now().Format(dayLayout)
+	ext := filepath.Ext(r.fileName)
+	return strings.TrimSuffix(r.fileName, ext) + "-" + day + ext, day
+}
+
+// open opens the current file, then flushes and closes the previous one, if any
+func (r *rotatingFile) open() error {
+	fileName, day := r.currentFileName()
+	f, err := openLogFile(fileName, r.Mode, r.Truncate)
+	if err != nil {
+		return err
+	}
+	var size int64
+	if info, err := f.Stat(); err == nil {
+		size = info.Size()
+	}
+	prev := r.file
+	r.file, r.size = f, size
+	r.day = day
+	if prev != nil {
+		r.retire(prev, prev.Name())
+	}
+	return nil
+}
+
+// rollOver renames the open file, which has reached MaxSize, to the first free numbered name, e.g. app.1.log for app.log,
+// and opens a new file of the current name
+func (r *rotatingFile) rollOver() error {
+	fileName := r.file.Name()
+	rolled := numberedFileName(fileName)
+	if err := os.Rename(fileName, rolled); err != nil {
+		return err
+	}
+	f, err := openLogFile(fileName, r.Mode, false)
+	if err != nil {
+		return err
+	}
+	prev := r.file
+	r.file, r.size = f, 0
+	r.retire(prev, rolled)
+	return nil
+}
+
+// retire flushes and closes f, a rotated out file now named fileName, and compresses it in the background if Compress is set.
+// The rotated out files older than MaxAgeDays are then removed.
+func (r *rotatingFile) retire(f *os.File, fileName string) {
+	f.Sync()
+	f.Close()
+	if r.MaxAgeDays > 0 {
+		r.removeExpired()
+	}
+	if r.Compress {
+		r.compressing.Add(1)
+		go func() {
+			defer r.compressing.Done()
+			compressFile(fileName)
+		}()
+	}
+}
+
+// rotatedFileNames returns the names of the files rotated out of fileName, compressed or not. Only the names alog produces
+// are selected, base-YYYY-MM-DD.ext and base-YYYY-MM-DD.N.ext for daily rotation and base.N.ext for size rotation,
+// so that other files sharing the base name, e.g. app-access.log next to app.log, are never picked up.
+func rotatedFileNames(fileName string) []string {
+	entries, err := os.ReadDir(filepath.Dir(fileName))
+	if err != nil {
+		return nil
+	}
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(filepath.Base(fileName), ext)
+	var names []string
+	for _, e := range entries {
+		if !e.IsDir() && isRotatedName(e.Name(), base, ext) {
+			names = append(names, filepath.Join(filepath.Dir(fileName), e.Name()))
+		}
+	}
+	return names
+}
+
+// isRotatedName reports whether name is a file rotated out of base+ext, see rotatedFileNames
+func isRotatedName(name, base, ext string) bool {
+	name = strings.TrimSuffix(name, ".gz")
+	if !strings.HasPrefix(name, base) || !strings.HasSuffix(name, ext) || len(name) < len(base)+len(ext) {
+		return false
+	}
+	stamp := name[len(base) : len(name)-len(ext)]
+	if strings.HasPrefix(stamp, "-") && len(stamp) >= 1+len(dayLayout) {
+		if _, err := time.Parse(dayLayout, stamp[1:1+len(dayLayout)]); err != nil {
+			return false
+		}
+		stamp = stamp[1+len(dayLayout):]
+		if len(stamp) == 0 {
+			return true
+		}
+	}
+	return strings.HasPrefix(stamp, ".") && isDigits(stamp[1:])
+}
+
+// isDigits reports whether s is a non empty string of decimal digits
+func isDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// removeExpired removes the rotated out files last modified more than MaxAgeDays ago, other than the open file
+func (r *rotatingFile) removeExpired() {
+	cutoff := r.now().AddDate(0, 0, -r.MaxAgeDays)
+	for _, name := range rotatedFileNames(r.fileName) {
+		if name == r.file.Name() {
+			continue
+		}
+		if info, err := os.Stat(name); err == nil && info.ModTime().Before(cutoff) {
+			if err := os.Remove(name); err != nil {
+				fmt.Fprintf(os.Stderr, "alog: unable to remove expired log file : %s. Error : %v\n", name, err)
+			}
+		}
+	}
+}
+
+// numberedFileName returns the first of app.1.log, app.2.log, ... for app.log which exists neither plain nor compressed
+func numberedFileName(fileName string) string {
+	ext := filepath.Ext(fileName)
+	base := strings.TrimSuffix(fileName, ext)
+	for n := 1; ; n++ {
+		name := fmt.Sprintf("%s.%d%s", base, n, ext)
+		if _, err := os.Stat(name); err == nil {
+			continue
+		}
+		if _, err := os.Stat(name + ".gz"); err == nil {
+			continue
+		}
+		return name
+	}
+}
+
+// Write rolls the file over first if it is due, either because the day has changed or because p would take the file past
+// MaxSize, whichever comes first. A new day starts an empty file, so that a single write never rotates twice.
+// A record larger than MaxSize is written as is to an empty file.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	switch {
+	case r.Rotation == RotateDaily && r.now().Format(dayLayout) != r.day:
+		err = r.open()
+	case r.MaxSize > 0 && r.size > 0 && r.size+int64(len(p)) > r.MaxSize:
+		err = r.rollOver()
+	}
+	if err != nil {
+		return 0, err
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Sync commits the current file to stable storage
+func (r *rotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Sync()
+}
+
+// Close closes the current file and waits for the compression of rotated out files to complete
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	err := r.file.Close()
+	r.mu.Unlock()
+	r.compressing.Wait()
+	return err
+}
+
+// compressFile gzip compresses fileName to fileName.gz and removes fileName on success.
+// On failure the plain file is left in place and a warning is written to stderr.
+func compressFile(fileName string) {
+	if err := gzipFile(fileName, fileName+".gz"); err != nil {
+		os.Remove(fileName + ".gz")
+		fmt.Fprintf(os.Stderr, "alog: unable to compress rotated log file : %s. Error : %v\n", fileName, err)
+		return
+	}
+	os.Remove(fileName)
+}
+
+func gzipFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0666)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	if _, err = io.Copy(zw, in); err == nil {
+		err = zw.Close()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
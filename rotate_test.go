@@ -0,0 +1,242 @@
+package alog
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDailyRotation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 31, 23, 59, 59, 0, time.Local)
+
+	r := &rotatingFile{fileName: filepath.Join(dir, "app.log"), FileOptions: FileOptions{Rotation: RotateDaily}, now: func() time.Time { return now }}
+	if err := r.open(); err != nil {
+		t.Fatalf("open() error = %v", err)
+	}
+	logger := New(r, INFO)
+	logger.file = r
+
+	logger.Info("before midnight")
+	now = now.Add(2 * time.Second)
+	logger.Info("after midnight")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for fileName, want := range map[string]string{
+		"app-2024-05-31.log": "before midnight",
+		"app-2024-06-01.log": "after midnight",
+	} {
+		b, err := os.ReadFile(filepath.Join(dir, fileName))
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", fileName, err)
+		}
+		if got := string(b); !strings.Contains(got, want) || strings.Count(got, "\n") != 1 {
+			t.Errorf("%s contents = %q, want only %q", fileName, got, want)
+		}
+	}
+}
+
+func TestParseRotation(t *testing.T) {
+	if got, err := ParseRotation("Daily"); err != nil || got != RotateDaily {
+		t.Errorf("ParseRotation(%q) = %v, %v, want RotateDaily", "Daily", got, err)
+	}
+	if _, err := ParseRotation("hourly"); err == nil {
+		t.Errorf("ParseRotation(%q) returned no error", "hourly")
+	}
+}
+
+func TestCompressRotatedFile(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 31, 23, 59, 59, 0, time.Local)
+
+	r := &rotatingFile{fileName: filepath.Join(dir, "app.log"), FileOptions: FileOptions{Rotation: RotateDaily, Compress: true}, now: func() time.Time { return now }}
+	if err := r.open(); err != nil {
+		t.Fatalf("open() error = %v", err)
+	}
+	logger := New(r, INFO)
+	logger.file = r
+
+	logger.Info("compress me")
+	now = now.Add(2 * time.Second)
+	logger.Info("keep me plain")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	rotated := filepath.Join(dir, "app-2024-05-31.log")
+	if _, err := os.Stat(rotated); !os.IsNotExist(err) {
+		t.Errorf("uncompressed %s still exists, err = %v", rotated, err)
+	}
+	f, err := os.Open(rotated + ".gz")
+	if err != nil {
+		t.Fatalf("unable to open compressed file: %v", err)
+	}
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("gzip.NewReader error = %v", err)
+	}
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("unable to decompress: %v", err)
+	}
+	if !strings.Contains(string(b), "[INFO] - compress me") {
+		t.Errorf("decompressed contents = %q, want the rotated out message", b)
+	}
+}
+
+func TestSizeAndDailyRotation(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 31, 12, 0, 0, 0, time.Local)
+	record := "- [INFO] - 0123456789\n"
+
+	opts := FileOptions{Rotation: RotateDaily, MaxSize: int64(2 * len(record))}
+	r := &rotatingFile{fileName: filepath.Join(dir, "app.log"), FileOptions: opts, now: func() time.Time { return now }}
+	if err := r.open(); err != nil {
+		t.Fatalf("open() error = %v", err)
+	}
+	logger := New(r, INFO)
+	logger.file = r
+	logger.SetFlags(0)
+
+	// The third record trips the size limit at noon, the fourth fits. The fifth trips both limits at once, after midnight.
+	for i := 0; i < 4; i++ {
+		logger.Info("0123456789")
+	}
+	now = now.Add(12 * time.Hour)
+	logger.Info("0123456789")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	want := map[string]int{"app-2024-05-31.1.log": 2, "app-2024-05-31.log": 2, "app-2024-06-01.log": 1}
+	if len(names) != len(want) {
+		t.Fatalf("files = %v, want a single size rotation and a single daily rotation", names)
+	}
+	for fileName, records := range want {
+		b, err := os.ReadFile(filepath.Join(dir, fileName))
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", fileName, err)
+		}
+		if got := string(b); got != strings.Repeat(record, records) {
+			t.Errorf("%s contents = %q, want %d records", fileName, got, records)
+		}
+	}
+}
+
+func TestSizeRotationWithoutDaily(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "app.log")
+	if err := os.WriteFile(filepath.Join(dir, "app.1.log"), []byte("older\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	logger, err := NewFileWithOptions(fileName, INFO, FileOptions{MaxSize: 10})
+	if err != nil {
+		t.Fatalf("NewFileWithOptions() error = %v", err)
+	}
+	logger.SetFlags(0)
+	logger.Info("first")
+	logger.Info("second")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for fileName, want := range map[string]string{"app.1.log": "older", "app.2.log": "first", "app.log": "second"} {
+		b, err := os.ReadFile(filepath.Join(dir, fileName))
+		if err != nil {
+			t.Fatalf("unable to read %s: %v", fileName, err)
+		}
+		if got := string(b); !strings.Contains(got, want) || strings.Count(got, "\n") != 1 {
+			t.Errorf("%s contents = %q, want only %q", fileName, got, want)
+		}
+	}
+}
+
+func TestRotationConfig(t *testing.T) {
+	conf := configSection{Rotate: "daily", MaxSize: "100", MaxAge: "30"}
+	if got, want := conf.config().File, (FileOptions{Rotation: RotateDaily, MaxSize: 100 << 20, MaxAgeDays: 30}); got != want {
+		t.Errorf("config().File = %+v, want %+v", got, want)
+	}
+}
+
+func TestMaxAgeDays(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2024, 5, 31, 23, 59, 59, 0, time.Local)
+
+	backdated := map[string]time.Time{
+		"app-2024-05-20.log":      now.AddDate(0, 0, -11),
+		"app-2024-05-21.1.log.gz": now.AddDate(0, 0, -10),
+		"app-2024-05-29.log":      now.AddDate(0, 0, -2),
+		"app.3.log":               now.AddDate(0, 0, -9),
+		"other.log":               now.AddDate(0, 0, -30),
+		"app-access.log":          now.AddDate(0, 0, -30),
+		"app.old-config.log":      now.AddDate(0, 0, -30),
+		"app-2024-13-01.log":      now.AddDate(0, 0, -30),
+		"app-2024-05-20.x.log.gz": now.AddDate(0, 0, -30),
+	}
+	for fileName, mtime := range backdated {
+		path := filepath.Join(dir, fileName)
+		if err := os.WriteFile(path, []byte("old\n"), 0666); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	opts := FileOptions{Rotation: RotateDaily, MaxAgeDays: 7}
+	r := &rotatingFile{fileName: filepath.Join(dir, "app.log"), FileOptions: opts, now: func() time.Time { return now }}
+	if err := r.open(); err != nil {
+		t.Fatalf("open() error = %v", err)
+	}
+	logger := New(r, INFO)
+	logger.file = r
+
+	logger.Info("before midnight")
+	for _, fileName := range []string{"app-2024-05-20.log", "app-2024-05-21.1.log.gz"} {
+		if _, err := os.Stat(filepath.Join(dir, fileName)); err != nil {
+			t.Errorf("%s removed before the first rotation, err = %v", fileName, err)
+		}
+	}
+	now = now.Add(2 * time.Second)
+	logger.Info("after midnight")
+	if err := logger.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	for fileName, kept := range map[string]bool{
+		"app-2024-05-20.log":      false,
+		"app-2024-05-21.1.log.gz": false,
+		"app-2024-05-29.log":      true,
+		"app-2024-05-31.log":      true,
+		"app-2024-06-01.log":      true,
+		"app.3.log":               false,
+		"other.log":               true,
+		"app-access.log":          true,
+		"app.old-config.log":      true,
+		"app-2024-13-01.log":      true,
+		"app-2024-05-20.x.log.gz": true,
+	} {
+		_, err := os.Stat(filepath.Join(dir, fileName))
+		if kept && err != nil {
+			t.Errorf("%s was removed, err = %v", fileName, err)
+		} else if !kept && !os.IsNotExist(err) {
+			t.Errorf("%s was kept, err = %v, want it removed as expired", fileName, err)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package alog
+
+import "sync/atomic"
+
+// sampler admits every nth call, counting calls atomically so that it can be shared by concurrent loggers
+type sampler struct {
+	n     uint64
+	count uint64
+}
+
+// sample reports whether the current call should be written: the 1st, (n+1)th, (2n+1)th, ... calls are
+func (s *sampler) sample() bool {
+	return (atomic.AddUint64(&s.count, 1)-1)%s.n == 0
+}
+
+// SetSampling makes the Logger write only every nth message at level, dropping the others.
+// This is meant for very chatty TRACE/DEBUG output. An n of 0 or 1 writes every message again.
+func (l *Logger) SetSampling(level LogLevel, n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if n <= 1 {
+		delete(l.sampling, level)
+		return
+	}
+	if l.sampling == nil {
+		l.sampling = make(map[LogLevel]*sampler)
+	}
+	l.sampling[level] = &sampler{n: uint64(n)}
+}
+
+// SetSampling makes the default Logger write only every nth message at level
+func SetSampling(level LogLevel, n int) {
+	std.SetSampling(level, n)
+}
@@ -0,0 +1,58 @@
+//go:build !alog_prod
+
+package alog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSampling(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetSampling(DEBUG, 10)
+
+	for i := 0; i < 100; i++ {
+		logger.Debugf("sampled %d", i)
+	}
+	logger.Info("not sampled")
+
+	if n := strings.Count(buf.String(), "[DEBUG]"); n != 10 {
+		t.Errorf("got %d DEBUG lines, want 10", n)
+	}
+	if !strings.Contains(buf.String(), "not sampled") {
+		t.Errorf("INFO message was dropped by DEBUG sampling")
+	}
+
+	buf.Reset()
+	logger.SetSampling(DEBUG, 0)
+	logger.Debug("a")
+	logger.Debug("b")
+	if n := strings.Count(buf.String(), "[DEBUG]"); n != 2 {
+		t.Errorf("got %d DEBUG lines after turning sampling off, want 2", n)
+	}
+}
+
+func TestSamplingConcurrent(t *testing.T) {
+	var buf lockedBuffer
+	logger := New(&buf, TRACE)
+	logger.SetSampling(TRACE, 10)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Trace("concurrent")
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := strings.Count(buf.String(), "\n"); n != 100 {
+		t.Errorf("got %d lines from 1000 sampled calls, want 100", n)
+	}
+}
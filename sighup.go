@@ -0,0 +1,56 @@
+//go:build !windows && !plan9
+
+package alog
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+)
+
+var (
+	sighupMu   sync.Mutex
+	sighupCh   chan os.Signal
+	sighupDone chan struct{}
+)
+
+// HandleSIGHUP starts a goroutine which calls Reopen on every SIGHUP the process receives, as logrotate expects after
+// it has renamed the log file. Calling HandleSIGHUP while it is already handling SIGHUP has no effect.
+// Use StopSIGHUP to restore the default handling of the signal.
+func HandleSIGHUP() {
+	sighupMu.Lock()
+	defer sighupMu.Unlock()
+
+	if sighupCh != nil {
+		return
+	}
+	sighupCh, sighupDone = make(chan os.Signal, 1), make(chan struct{})
+	signal.Notify(sighupCh, syscall.SIGHUP)
+	go handleSIGHUP(sighupCh, sighupDone)
+}
+
+// StopSIGHUP halts the handling of SIGHUP started by HandleSIGHUP. It is safe to call more than once.
+func StopSIGHUP() {
+	sighupMu.Lock()
+	defer sighupMu.Unlock()
+
+	if sighupCh == nil {
+		return
+	}
+	signal.Stop(sighupCh)
+	close(sighupCh)
+	<-sighupDone
+	sighupCh, sighupDone = nil, nil
+}
+
+func handleSIGHUP(signals <-chan os.Signal, done chan<- struct{}) {
+	defer close(done)
+
+	for range signals {
+		if err := Reopen(); err != nil {
+			fmt.Fprintf(os.Stderr, "alog: unable to reopen log file on SIGHUP. Error : %v\n", err)
+		}
+	}
+}
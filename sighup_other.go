@@ -0,0 +1,9 @@
+//go:build windows || plan9
+
+package alog
+
+// HandleSIGHUP has no effect on platforms without SIGHUP, such as Windows. Call Reopen instead.
+func HandleSIGHUP() {}
+
+// StopSIGHUP has no effect on platforms without SIGHUP
+func StopSIGHUP() {}
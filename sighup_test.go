@@ -0,0 +1,41 @@
+//go:build !windows && !plan9
+
+package alog
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestHandleSIGHUP(t *testing.T) {
+	restoreDefaultLogger(t)
+	fileName := filepath.Join(t.TempDir(), "app.log")
+	if err := Configure(Config{Level: INFO, FileName: fileName, Flags: DefaultFlags}); err != nil {
+		t.Fatalf("Configure() error = %v", err)
+	}
+
+	HandleSIGHUP()
+	defer StopSIGHUP()
+	HandleSIGHUP()
+
+	if err := os.Rename(fileName, fileName+".1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+	for deadline := time.Now().Add(5 * time.Second); !fileExists(fileName); time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("%s was not reopened after SIGHUP", fileName)
+		}
+	}
+
+	Info("after SIGHUP")
+	if b, err := os.ReadFile(fileName); err != nil || !strings.Contains(string(b), "after SIGHUP") {
+		t.Errorf("reopened file contents = %q, %v, want the record logged after SIGHUP", b, err)
+	}
+}
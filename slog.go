@@ -0,0 +1,121 @@
+package alog
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+)
+
+// slogHandler is a slog.Handler which writes slog records through a Logger
+type slogHandler struct {
+	logger *Logger
+	fields Fields
+	// group is the prefix, e.g. "request.", of the keys of attributes added under WithGroup
+	group string
+}
+
+// SlogHandler returns a slog.Handler which writes slog records through the Logger, subject to its level, format and destinations.
+// Attributes are written as structured fields, with the keys of grouped attributes prefixed by the group name, e.g. request.method.
+func (l *Logger) SlogHandler() slog.Handler {
+	return &slogHandler{logger: l}
+}
+
+// NewSlogHandler returns a slog.Handler which writes slog records through the default Logger :
+//
+//	slog.SetDefault(slog.New(alog.NewSlogHandler()))
+func NewSlogHandler() slog.Handler {
+	return std.SlogHandler()
+}
+
+// slogLevel maps a slog level to the alog level at or below it.
+// Levels below slog.LevelDebug map to TRACE and levels well above slog.LevelError map to CRITICAL.
+// Levels from slog.LevelInfo+2 up to slog.LevelWarn, which slog has no name for, map to NOTICE.
+func slogLevel(level slog.Level) LogLevel {
+	switch {
+	case level < slog.LevelDebug:
+		return TRACE
+	case level < slog.LevelInfo:
+		return DEBUG
+	case level < slog.LevelInfo+2:
+		return INFO
+	case level < slog.LevelWarn:
+		return NOTICE
+	case level < slog.LevelError:
+		return WARN
+	case level < slog.LevelError+4:
+		return ERROR
+	default:
+		return CRITICAL
+	}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.IsEnabled(slogLevel(level))
+}
+
+func (h *slogHandler) Handle(_ context.Context, r slog.Record) error {
+	level := slogLevel(r.Level)
+	if !h.logger.IsEnabled(level) {
+		return nil
+	}
+	s := h.logger.settings(level)
+	if !s.sampled() {
+		return nil
+	}
+
+	fields := make(Fields, len(h.fields)+r.NumAttrs())
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		addAttr(fields, h.group, a)
+		return true
+	})
+
+	var callSite string
+	if s.callerInfo && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		callSite = fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line)
+	}
+
+	h.logger.write(s, level, fields, callSite, r.Message, h.logger.writeRecord)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(Fields, len(h.fields)+len(attrs))
+	for k, v := range h.fields {
+		fields[k] = v
+	}
+	for _, a := range attrs {
+		addAttr(fields, h.group, a)
+	}
+	return &slogHandler{logger: h.logger, fields: fields, group: h.group}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if len(name) == 0 {
+		return h
+	}
+	return &slogHandler{logger: h.logger, fields: h.fields, group: h.group + name + "."}
+}
+
+// addAttr adds the attribute to fields, flattening groups into prefixed keys
+func addAttr(fields Fields, prefix string, a slog.Attr) {
+	v := a.Value.Resolve()
+	if v.Kind() == slog.KindGroup {
+		if len(a.Key) != 0 {
+			prefix += a.Key + "."
+		}
+		for _, ga := range v.Group() {
+			addAttr(fields, prefix, ga)
+		}
+		return
+	}
+	if len(a.Key) == 0 {
+		return
+	}
+	fields[prefix+a.Key] = v.Any()
+}
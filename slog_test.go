@@ -0,0 +1,50 @@
+package alog
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestSlogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	sl := slog.New(logger.SlogHandler())
+
+	sl.Debug("suppressed")
+	sl.Info("user login", "user", 42)
+	sl.With("service", "auth").WithGroup("request").Warn("slow", "method", "GET", slog.Group("timing", "ms", 1500))
+	sl.Log(context.Background(), slog.LevelError+4, "disk gone")
+
+	want := "- [INFO] - user login user=42\n" +
+		"- [WARN] - slow request.method=GET request.timing.ms=1500 service=auth\n" +
+		"- [CRITICAL] - disk gone\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	for _, tt := range []struct {
+		level slog.Level
+		want  LogLevel
+	}{
+		{slog.LevelDebug - 4, TRACE},
+		{slog.LevelDebug, DEBUG},
+		{slog.LevelInfo, INFO},
+		{slog.LevelWarn, WARN},
+		{slog.LevelError, ERROR},
+		{slog.LevelError + 4, CRITICAL},
+	} {
+		if got := slogLevel(tt.level); got != tt.want {
+			t.Errorf("slogLevel(%v) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+
+	h := New(&bytes.Buffer{}, WARN).SlogHandler()
+	if h.Enabled(context.Background(), slog.LevelInfo) || !h.Enabled(context.Background(), slog.LevelError) {
+		t.Errorf("Enabled does not follow the WARN level of the Logger")
+	}
+}
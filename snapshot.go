@@ -0,0 +1,87 @@
+package alog
+
+import (
+	"fmt"
+	"os"
+)
+
+// ConfigSnapshot is a read-only copy of the effective configuration of a Logger, for diagnostics, e.g. to find out
+// where records are going. It is taken by (*Logger).Config and CurrentConfig.
+type ConfigSnapshot struct {
+	// Level is the level at or above which messages are written, OFF while the Logger is disabled
+	Level    LogLevel
+	Disabled bool
+	// Destination describes the main destination: "stdout", "stderr", the name of the log file or the type of the writer
+	Destination string
+	// AddedDestinations is the number of destinations added with AddDestination
+	AddedDestinations int
+	// FileName and File are the log file opened by alog and its options, if any
+	FileName   string
+	File       FileOptions
+	Format     Format
+	Formatter  bool
+	Flags      int
+	TimeFormat string
+	Timestamp  bool
+	Prefix     string
+	Color      ColorMode
+	CallerInfo bool
+	// StackTrace is the level at or above which records carry a stack trace, OFF for none
+	StackTrace LogLevel
+	Async      bool
+	Syslog     bool
+	RingBuffer bool
+}
+
+// describeDestination returns a description of w for ConfigSnapshot.Destination
+func describeDestination(w interface{}) string {
+	switch w {
+	case os.Stdout:
+		return "stdout"
+	case os.Stderr:
+		return "stderr"
+	}
+	if f, ok := w.(*os.File); ok {
+		return f.Name()
+	}
+	return fmt.Sprintf("%T", w)
+}
+
+// Config returns a snapshot of the effective configuration of the Logger
+func (l *Logger) Config() ConfigSnapshot {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	c := ConfigSnapshot{
+		Level:             l.level,
+		Disabled:          l.disabledLevel != nil,
+		Destination:       describeDestination(l.out),
+		AddedDestinations: len(l.extra),
+		Format:            l.format,
+		Formatter:         l.formatter != nil,
+		Flags:             l.flags,
+		TimeFormat:        l.timeFormat,
+		Timestamp:         !l.noTimestamp,
+		Prefix:            l.prefix,
+		Color:             l.colorMode,
+		CallerInfo:        l.callerInfo,
+		StackTrace:        l.stackLevel,
+		Async:             l.async != nil,
+		Syslog:            l.syslog != nil,
+		RingBuffer:        l.ring != nil,
+	}
+	if l.file != nil {
+		c.FileName, c.File = l.fileName, l.fileOpts
+		// After SetLogDestination, the records go to the writer set rather than to the file which is still open
+		if l.out == l.file {
+			c.Destination = l.fileName
+		}
+	}
+	return c
+}
+
+// CurrentConfig returns a snapshot of the effective configuration of the default Logger.
+// It is not named Config, which is the type taken by Configure.
+func CurrentConfig() ConfigSnapshot {
+	return std.Config()
+}
@@ -0,0 +1,66 @@
+package alog
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigSnapshot(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, WARN)
+	logger.SetFormat(FormatLogfmt)
+	logger.SetFlags(log.Ldate | log.LUTC)
+	logger.SetPrefix("app: ")
+	logger.AddDestination(&bytes.Buffer{}, TRACE)
+	logger.SetStackTrace(ERROR)
+	logger.EnableAsync(8)
+	defer logger.Close()
+
+	c := logger.Config()
+	want := ConfigSnapshot{
+		Level:             WARN,
+		Destination:       "*bytes.Buffer",
+		AddedDestinations: 1,
+		Format:            FormatLogfmt,
+		Flags:             log.Ldate | log.LUTC,
+		Timestamp:         true,
+		Prefix:            "app: ",
+		StackTrace:        ERROR,
+		Async:             true,
+	}
+	if c != want {
+		t.Errorf("Config() = %+v, want %+v", c, want)
+	}
+
+	logger.Disable()
+	if c := logger.Config(); !c.Disabled || c.Level != OFF {
+		t.Errorf("Config() of a disabled Logger = %+v, want Disabled at OFF", c)
+	}
+}
+
+func TestConfigSnapshotFile(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "app.log")
+	opts := FileOptions{Rotation: RotateDaily, Compress: true}
+	logger, err := NewFileWithOptions(fileName, INFO, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer logger.Close()
+
+	c := logger.Config()
+	if c.Destination != fileName || c.FileName != fileName || c.File != opts {
+		t.Errorf("Config() = %+v, want the file %s with %+v", c, fileName, opts)
+	}
+
+	logger.SetLogDestination(os.Stdout)
+	if c := logger.Config(); c.Destination != "stdout" || c.FileName != fileName {
+		t.Errorf("Config() after SetLogDestination = %+v, want the stdout destination and the open file %s", c, fileName)
+	}
+
+	if got := New(os.Stderr, INFO).Config().Destination; got != "stderr" {
+		t.Errorf("Destination of a Logger writing to os.Stderr = %q, want stderr", got)
+	}
+}
@@ -0,0 +1,57 @@
+package alog
+
+import (
+	"bytes"
+	"runtime"
+	"strings"
+)
+
+// stackField is the field which carries the stack trace of a record, set with SetStackTrace
+const stackField = "stack"
+
+// stackTrace is the type of the stackField value, which tells it apart from a field of the same name given by the user
+type stackTrace string
+
+// stack returns the stack trace of the current goroutine from the user's call site down,
+// leaving out the "goroutine N [running]:" header and the frames of alog itself, as caller does.
+func stack() stackTrace {
+	buf := make([]byte, 4096)
+	for {
+		n := runtime.Stack(buf, false)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	// Each frame takes two lines, the function and then its file:line
+	lines := strings.SplitAfterN(strings.TrimRight(string(buf), "\n"), "\n", 2+2*callerDepth)
+	return stackTrace(lines[len(lines)-1])
+}
+
+// writeStackTrace appends the stack trace among the fields, if any, on the lines following a text record, each terminated by ending
+func writeStackTrace(buf *bytes.Buffer, fields Fields, ending string) {
+	if st, ok := fields[stackField].(stackTrace); ok {
+		trace := string(st)
+		if ending != "\n" {
+			trace = strings.ReplaceAll(trace, "\n", ending)
+		}
+		buf.WriteString(trace)
+		buf.WriteString(ending)
+	}
+}
+
+// SetStackTrace makes the Logger add the stack trace of the calling goroutine to every record at or above minLevel.
+// Text records are followed by the stack trace on the lines beneath them, JSON and logfmt records carry it in a "stack" field.
+// SetStackTrace(OFF), the default, turns stack traces off.
+func (l *Logger) SetStackTrace(minLevel LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stackLevel = minLevel
+}
+
+// SetStackTrace makes the default Logger add stack traces to the records at or above minLevel
+func SetStackTrace(minLevel LogLevel) {
+	std.SetStackTrace(minLevel)
+}
@@ -0,0 +1,64 @@
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestSetStackTrace(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetStackTrace(ERROR)
+
+	logger.Warn("no stack")
+	logger.Error("with stack")
+
+	lines := strings.Split(buf.String(), "\n")
+	if lines[0] != "- [WARN] - no stack" || lines[1] != "- [ERROR] - with stack" {
+		t.Fatalf("output = %q, want the records first on their lines", buf.String())
+	}
+	trace := strings.Join(lines[2:], "\n")
+	if !strings.HasPrefix(trace, "github.com/en-vee/alog.TestSetStackTrace(") {
+		t.Errorf("stack trace = %q, want it to start at TestSetStackTrace", trace)
+	}
+	if strings.Contains(trace, "logMsg") {
+		t.Errorf("stack trace = %q, want the frames of alog left out", trace)
+	}
+}
+
+func TestSetStackTraceJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFormat(FormatJSON)
+	logger.SetStackTrace(ERROR)
+
+	logger.Critical("failed")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("output %q is not JSON: %v", buf.String(), err)
+	}
+	if rec["msg"] != "failed" {
+		t.Errorf("msg = %q, want the message only", rec["msg"])
+	}
+	if stack, _ := rec["stack"].(string); !strings.Contains(stack, "TestSetStackTraceJSON") {
+		t.Errorf("stack = %q, want it to contain TestSetStackTraceJSON", stack)
+	}
+}
+
+func TestStackTraceOffByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	logger.Critical("no stack")
+	logger.WithFields(Fields{"stack": "user value"}).Error("user field")
+
+	want := "- [CRITICAL] - no stack\n- [ERROR] - user field stack=user value\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
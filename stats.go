@@ -0,0 +1,30 @@
+package alog
+
+import "sync/atomic"
+
+// Stats returns the number of records written by the Logger at each level since it was created or since ResetStats.
+// Suppressed and dropped messages are not counted.
+func (l *Logger) Stats() map[LogLevel]uint64 {
+	stats := make(map[LogLevel]uint64, len(l.counts))
+	for level := range l.counts {
+		stats[LogLevel(level)] = atomic.LoadUint64(&l.counts[level])
+	}
+	return stats
+}
+
+// ResetStats sets all the counters returned by Stats back to zero
+func (l *Logger) ResetStats() {
+	for level := range l.counts {
+		atomic.StoreUint64(&l.counts[level], 0)
+	}
+}
+
+// Stats returns the number of records written by the default Logger at each level since process start or since ResetStats
+func Stats() map[LogLevel]uint64 {
+	return std.Stats()
+}
+
+// ResetStats sets the counters of the default Logger back to zero
+func ResetStats() {
+	std.ResetStats()
+}
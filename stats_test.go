@@ -0,0 +1,32 @@
+//go:build !alog_prod
+
+package alog
+
+import (
+	"io"
+	"testing"
+)
+
+func TestStats(t *testing.T) {
+	logger := New(io.Discard, DEBUG)
+
+	for i := 0; i < 3; i++ {
+		logger.Trace("suppressed")
+		logger.Debug("debug")
+	}
+	logger.Warn("warn")
+	logger.WithFields(Fields{"k": "v"}).Critical("critical")
+
+	want := map[LogLevel]uint64{TRACE: 0, DEBUG: 3, INFO: 0, WARN: 1, ERROR: 0, CRITICAL: 1}
+	got := logger.Stats()
+	for level, n := range want {
+		if got[level] != n {
+			t.Errorf("Stats()[%v] = %d, want %d", level, got[level], n)
+		}
+	}
+
+	logger.ResetStats()
+	if got := logger.Stats(); got[DEBUG] != 0 || got[CRITICAL] != 0 {
+		t.Errorf("Stats() after ResetStats = %v, want all zero", got)
+	}
+}
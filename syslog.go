@@ -0,0 +1,40 @@
+package alog
+
+// levelledWriter is a destination which is told the level of each record, such as syslog, which maps it to a priority
+type levelledWriter interface {
+	writeLevel(level LogLevel, b []byte) error
+	Close() error
+}
+
+// SetSyslog makes the Logger also send every record to syslog, with a priority according to its level:
+// CRITICAL is LOG_CRIT, ERROR LOG_ERR, WARN LOG_WARNING, NOTICE LOG_NOTICE, INFO LOG_INFO, DEBUG and TRACE LOG_DEBUG.
+// network and addr are as for net.Dial, e.g. "udp" and "loghost:514", or both empty for the local syslog daemon.
+// Syslog is one of the destinations of the Logger, written through the async writer if EnableAsync is on, and a failed send is
+// handled as for the other destinations. Records are sent as they are written to the other destinations, so SetFlags(0)
+// avoids a second timestamp. A previous syslog connection is closed. On platforms without syslog, such as Windows, an error
+// is returned.
+func (l *Logger) SetSyslog(network, addr, tag string) error {
+	w, err := dialSyslog(network, addr, tag)
+	if err != nil {
+		return err
+	}
+
+	// Holding writeMu keeps records from being sent to the previous connection while it is closed, as in Close
+	l.writeMu.Lock()
+	defer l.writeMu.Unlock()
+	l.mu.Lock()
+	previous := l.syslog
+	l.syslog = w
+	l.updateOutput()
+	l.mu.Unlock()
+
+	if previous != nil {
+		previous.Close()
+	}
+	return nil
+}
+
+// SetSyslog makes the default Logger also send every record to syslog
+func SetSyslog(network, addr, tag string) error {
+	return std.SetSyslog(network, addr, tag)
+}
@@ -0,0 +1,9 @@
+//go:build windows || plan9
+
+package alog
+
+import "errors"
+
+func dialSyslog(network, addr, tag string) (levelledWriter, error) {
+	return nil, errors.New("alog: syslog is not supported on this platform")
+}
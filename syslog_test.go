@@ -0,0 +1,84 @@
+package alog
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+)
+
+// fakeSyslog is a syslog connection which records the level of each record sent to it.
+// Sends wait until release is closed, if it is set.
+type fakeSyslog struct {
+	mu      sync.Mutex
+	levels  []LogLevel
+	err     error
+	release chan struct{}
+}
+
+func (s *fakeSyslog) writeLevel(level LogLevel, b []byte) error {
+	if s.release != nil {
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.levels = append(s.levels, level)
+	return s.err
+}
+
+func (s *fakeSyslog) Close() error {
+	return nil
+}
+
+func (s *fakeSyslog) sent() []LogLevel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]LogLevel(nil), s.levels...)
+}
+
+// setFakeSyslog stands in for SetSyslog, which needs a syslog daemon
+func setFakeSyslog(l *Logger, s *fakeSyslog) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.syslog = s
+	l.updateOutput()
+}
+
+func TestSyslogError(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	sys := &fakeSyslog{err: errors.New("syslog unreachable")}
+	setFakeSyslog(logger, sys)
+
+	if err := logger.TryWarn("not sent"); err != sys.err {
+		t.Errorf("TryWarn() error = %v, want the syslog error", err)
+	}
+	if got, want := buf.String(), "- [WARN] - not sent\n"; got != want {
+		t.Errorf("destination output = %q, want %q", got, want)
+	}
+	if got := sys.sent(); len(got) != 1 || got[0] != WARN {
+		t.Errorf("levels sent to syslog = %v, want [WARN]", got)
+	}
+}
+
+func TestSyslogAsync(t *testing.T) {
+	var buf lockedBuffer
+	logger := New(&buf, INFO)
+	sys := &fakeSyslog{release: make(chan struct{})}
+	setFakeSyslog(logger, sys)
+	logger.EnableAsync(16)
+	defer logger.DisableAsync()
+
+	// The records are queued while syslog blocks the background writer
+	logger.Error("first")
+	logger.Info("second")
+	close(sys.release)
+	if err := logger.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if got := sys.sent(); len(got) != 2 || got[0] != ERROR || got[1] != INFO {
+		t.Errorf("levels sent to syslog = %v, want [ERROR INFO]", got)
+	}
+}
@@ -0,0 +1,40 @@
+//go:build !windows && !plan9
+
+package alog
+
+import "log/syslog"
+
+// syslogWriter sends records to syslog at the priority of their level
+type syslogWriter struct {
+	w *syslog.Writer
+}
+
+func dialSyslog(network, addr, tag string) (levelledWriter, error) {
+	w, err := syslog.Dial(network, addr, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &syslogWriter{w: w}, nil
+}
+
+func (s *syslogWriter) writeLevel(level LogLevel, b []byte) error {
+	msg := string(b)
+	switch level {
+	case CRITICAL:
+		return s.w.Crit(msg)
+	case ERROR:
+		return s.w.Err(msg)
+	case WARN:
+		return s.w.Warning(msg)
+	case NOTICE:
+		return s.w.Notice(msg)
+	case INFO:
+		return s.w.Info(msg)
+	default:
+		return s.w.Debug(msg)
+	}
+}
+
+func (s *syslogWriter) Close() error {
+	return s.w.Close()
+}
@@ -0,0 +1,53 @@
+//go:build !windows && !plan9
+
+package alog
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSetSyslog(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("unable to listen for syslog datagrams: %v", err)
+	}
+	defer conn.Close()
+
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	if err := logger.SetSyslog("udp", conn.LocalAddr().String(), "alogtest"); err != nil {
+		t.Fatalf("SetSyslog() error = %v", err)
+	}
+	defer logger.Close()
+
+	tests := []struct {
+		log      func(...interface{})
+		priority string
+	}{
+		{logger.Error, "<11>"},    // LOG_USER|LOG_ERR
+		{logger.Critical, "<10>"}, // LOG_USER|LOG_CRIT
+		{logger.Info, "<14>"},     // LOG_USER|LOG_INFO
+	}
+	packet := make([]byte, 1024)
+	for _, tt := range tests {
+		tt.log("sent to syslog")
+
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(packet)
+		if err != nil {
+			t.Fatalf("reading the syslog datagram: %v", err)
+		}
+		got := string(packet[:n])
+		if !strings.HasPrefix(got, tt.priority) || !strings.Contains(got, "alogtest") || !strings.Contains(got, "sent to syslog") {
+			t.Errorf("syslog datagram = %q, want priority %s, the tag and the message", got, tt.priority)
+		}
+	}
+	if !strings.Contains(buf.String(), "[ERROR] - sent to syslog") {
+		t.Errorf("destination output = %q, want the records there as well", buf.String())
+	}
+}
@@ -0,0 +1,83 @@
+package alog
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+)
+
+// TB is the subset of testing.TB used by NewTestLogger, so that alog does not need to import the testing package.
+// *testing.T, *testing.B and *testing.F all implement it.
+type TB interface {
+	Helper()
+	Log(args ...interface{})
+	Cleanup(func())
+}
+
+// TestLogger is a Logger for tests, which captures its records so that they can be asserted on
+// and also passes them to the Log method of the test, so that they are shown when the test fails or with go test -v.
+type TestLogger struct {
+	*Logger
+	w *testWriter
+}
+
+// testWriter keeps the records written to a TestLogger and forwards them to its test until the test has finished
+type testWriter struct {
+	mu   sync.Mutex
+	tb   TB
+	buf  bytes.Buffer
+	done bool
+}
+
+func (w *testWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.buf.Write(p)
+	if !w.done {
+		w.tb.Log(strings.TrimSuffix(string(p), "\n"))
+	}
+	return len(p), nil
+}
+
+// NewTestLogger creates a TestLogger which logs at every level, without timestamps, to tb and to its own buffer, e.g.
+//
+//	logger := alog.NewTestLogger(t)
+//	codeUnderTest(logger.Logger)
+//	if !strings.Contains(logger.Output(), "[ERROR] - connection refused") { ... }
+//
+// Records written after the test has finished, e.g. by a goroutine which outlived it, are captured but not passed to tb.
+func NewTestLogger(tb TB) *TestLogger {
+	tb.Helper()
+	w := &testWriter{tb: tb}
+	tb.Cleanup(func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		w.done = true
+	})
+	l := New(w, TRACE)
+	l.SetFlags(0)
+	return &TestLogger{Logger: l, w: w}
+}
+
+// Output returns everything written to the TestLogger so far
+func (t *TestLogger) Output() string {
+	t.w.mu.Lock()
+	defer t.w.mu.Unlock()
+	return t.w.buf.String()
+}
+
+// Lines returns the records written to the TestLogger so far, one per element, without their trailing newlines
+func (t *TestLogger) Lines() []string {
+	out := strings.TrimSuffix(t.Output(), "\n")
+	if len(out) == 0 {
+		return nil
+	}
+	return strings.Split(out, "\n")
+}
+
+// ResetOutput discards the records captured so far
+func (t *TestLogger) ResetOutput() {
+	t.w.mu.Lock()
+	defer t.w.mu.Unlock()
+	t.w.buf.Reset()
+}
@@ -0,0 +1,55 @@
+package alog
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recordingTB is a TB which keeps the logged messages and cleanups, in place of a real test
+type recordingTB struct {
+	logged   []string
+	cleanups []func()
+}
+
+func (r *recordingTB) Helper()                 {}
+func (r *recordingTB) Log(args ...interface{}) { r.logged = append(r.logged, args[0].(string)) }
+func (r *recordingTB) Cleanup(f func())        { r.cleanups = append(r.cleanups, f) }
+
+func TestNewTestLogger(t *testing.T) {
+	logger := NewTestLogger(t)
+
+	logger.Info("starting")
+	logger.WithFields(Fields{"host": "db1"}).Error("connection refused")
+
+	want := []string{"- [INFO] - starting", "- [ERROR] - connection refused host=db1"}
+	if got := logger.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+	if !strings.Contains(logger.Output(), "connection refused") {
+		t.Errorf("Output() = %q, want the ERROR record", logger.Output())
+	}
+
+	logger.ResetOutput()
+	if got := logger.Lines(); got != nil {
+		t.Errorf("Lines() after ResetOutput = %q, want none", got)
+	}
+}
+
+func TestTestLoggerForwardsUntilCleanup(t *testing.T) {
+	tb := &recordingTB{}
+	logger := NewTestLogger(tb)
+
+	logger.Warn("during the test")
+	for _, f := range tb.cleanups {
+		f()
+	}
+	logger.Warn("after the test")
+
+	if want := []string{"- [WARN] - during the test"}; !reflect.DeepEqual(tb.logged, want) {
+		t.Errorf("logged to tb = %q, want %q", tb.logged, want)
+	}
+	if got := len(logger.Lines()); got != 2 {
+		t.Errorf("captured %d lines, want 2", got)
+	}
+}
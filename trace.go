@@ -0,0 +1,65 @@
+//go:build !alog_prod
+
+package alog
+
+// Trace and Debug are kept apart from the other levels so that the alog_prod build tag can strip them, see trace_prod.go
+
+func (l *Logger) Trace(args ...interface{}) {
+	var level LogLevel = TRACE
+	// Select Function based on level
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, l.sprint(args...))
+	}
+}
+
+func (l *Logger) Tracef(format string, objs ...interface{}) {
+	var level LogLevel = TRACE
+	// Select Function based on level
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, format, objs...)
+	}
+}
+
+func (l *Logger) Debug(args ...interface{}) {
+	var level = DEBUG
+	// Select Function based on slice
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, l.sprint(args...))
+	}
+}
+
+func (l *Logger) Debugf(format string, objs ...interface{}) {
+	var level = DEBUG
+	// Select Function based on slice
+	if logFunc := l.logFunc(level); logFunc != nil {
+		logFunc(l, level, nil, format, objs...)
+	}
+}
+
+func Trace(args ...interface{}) {
+	var level LogLevel = TRACE
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, std.sprint(args...))
+	}
+}
+
+func Tracef(format string, objs ...interface{}) {
+	var level LogLevel = TRACE
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, format, objs...)
+	}
+}
+
+func Debug(args ...interface{}) {
+	var level LogLevel = DEBUG
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, std.sprint(args...))
+	}
+}
+
+func Debugf(format string, objs ...interface{}) {
+	var level LogLevel = DEBUG
+	if logFunc := std.logFunc(level); logFunc != nil {
+		logFunc(std, level, nil, format, objs...)
+	}
+}
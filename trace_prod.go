@@ -0,0 +1,26 @@
+//go:build alog_prod
+
+package alog
+
+// With the alog_prod build tag, Trace, Tracef, Debug and Debugf, of the Logger and of the package, are empty functions which the compiler
+// inlines away, so that TRACE and DEBUG calls cost nothing, not even a level check, whatever the level is set to.
+// Their args are still evaluated, so expensive ones should be guarded with IsDebugEnabled, which reports the level as usual.
+// The other ways of logging at TRACE and DEBUG, such as TraceLn, DebugKV, Entry.Debug and LogBytes, are not stripped.
+//
+//	go build -tags alog_prod
+
+func (l *Logger) Trace(args ...interface{}) {}
+
+func (l *Logger) Tracef(format string, objs ...interface{}) {}
+
+func (l *Logger) Debug(args ...interface{}) {}
+
+func (l *Logger) Debugf(format string, objs ...interface{}) {}
+
+func Trace(args ...interface{}) {}
+
+func Tracef(format string, objs ...interface{}) {}
+
+func Debug(args ...interface{}) {}
+
+func Debugf(format string, objs ...interface{}) {}
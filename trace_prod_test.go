@@ -0,0 +1,25 @@
+//go:build alog_prod
+
+package alog
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTraceDebugStripped(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, TRACE)
+	logger.SetFlags(0)
+
+	logger.Tracef("stripped %d", 1)
+	logger.Debugf("stripped %d", 2)
+	logger.Info("kept")
+
+	if got, want := buf.String(), "- [INFO] - kept\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+	if !logger.IsDebugEnabled() {
+		t.Errorf("IsDebugEnabled() = false, want the level reported as usual")
+	}
+}
@@ -0,0 +1,184 @@
+//go:build !alog_prod
+
+package alog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestNamed(t *testing.T) {
+	var buf bytes.Buffer
+	parent := New(&buf, INFO)
+	parent.SetFlags(0)
+	db := parent.Named("db")
+	pool := db.Named("pool")
+
+	parent.Info("from parent")
+	db.Warn("from db")
+	pool.Error("from pool")
+	pool.Debug("suppressed by the shared level")
+
+	want := "- [INFO] - from parent\n" +
+		"[db] - [WARN] - from db\n" +
+		"[db.pool] - [ERROR] - from pool\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	parent.SetLogLevel(DEBUG)
+	parent.SetFormat(FormatJSON)
+	pool.Debug("level and format shared")
+
+	var rec map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil || rec["logger"] != "db.pool" {
+		t.Errorf("JSON output = %q, %v, want a logger field of db.pool", buf.String(), err)
+	}
+}
+
+func TestCallerInfoDefaultLogger(t *testing.T) {
+	var buf bytes.Buffer
+	defer SetLogDestination(os.Stdout)
+	defer SetLogLevel(GetLogLevel())
+	SetLogDestination(&buf)
+	SetLogLevel(TRACE)
+	SetCallerInfo(true)
+	defer SetCallerInfo(false)
+
+	_, _, line, _ := runtime.Caller(0)
+	Debug("from the default logger")
+
+	if want := fmt.Sprintf("trace_test.go:%d - [DEBUG]", line+1); !strings.Contains(buf.String(), want) {
+		t.Errorf("output = %q, want it to contain %q", buf.String(), want)
+	}
+}
+
+func TestNoConfigUsesDefaultLevel(t *testing.T) {
+	if got := newDefaultLogger().GetLogLevel(); got != TRACE {
+		t.Errorf("initial level of the default Logger = %v, want TRACE", got)
+	}
+
+	chdirTemp(t)
+	restoreDefaultLogger(t)
+	SetLogLevel(CRITICAL)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() without alog.conf error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	SetLogDestination(&buf)
+	Trace("trace with no config")
+	Info("info with no config")
+
+	if got := buf.String(); !strings.Contains(got, "[TRACE] - trace with no config") || !strings.Contains(got, "[INFO] - info with no config") {
+		t.Errorf("output = %q, want both the TRACE and INFO messages", got)
+	}
+}
+
+func TestLoggerFromConfig(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	writeConfig(t, dir, `alog {
+    logLevel = "ERROR"
+    http {
+        fileName = "http.log"
+        logLevel = "DEBUG"
+    }
+    db {
+        fileName = "db.log"
+        logLevel = "WARN"
+    }
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+	if got := GetLogLevel(); got != ERROR {
+		t.Errorf("level of the default Logger = %v, want ERROR from the alog block", got)
+	}
+
+	httpLogger, err := LoggerFromConfig("http")
+	if err != nil {
+		t.Fatalf("LoggerFromConfig(%q) error = %v", "http", err)
+	}
+	defer httpLogger.Close()
+	dbLogger, err := LoggerFromConfig("db")
+	if err != nil {
+		t.Fatalf("LoggerFromConfig(%q) error = %v", "db", err)
+	}
+	defer dbLogger.Close()
+
+	if got := httpLogger.GetLogLevel(); got != DEBUG {
+		t.Errorf("level of the http Logger = %v, want DEBUG", got)
+	}
+	if got := dbLogger.GetLogLevel(); got != WARN {
+		t.Errorf("level of the db Logger = %v, want WARN", got)
+	}
+	httpLogger.Debug("GET /")
+	dbLogger.Info("suppressed")
+	dbLogger.Warn("slow query")
+	httpLogger.Close()
+	dbLogger.Close()
+
+	for fileName, want := range map[string]string{"http.log": "[DEBUG] - GET /\n", "db.log": "[WARN] - slow query\n"} {
+		b, err := os.ReadFile(filepath.Join(dir, fileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(b); !strings.HasSuffix(got, want) || strings.Count(got, "\n") != 1 {
+			t.Errorf("%s = %q, want a single record ending in %q", fileName, got, want)
+		}
+	}
+
+	missing, err := LoggerFromConfig("missing")
+	if err != nil {
+		t.Fatalf("LoggerFromConfig(%q) error = %v", "missing", err)
+	}
+	if got := missing.GetLogDestination(); got != os.Stdout || missing.GetLogLevel() != defaultLogLevel {
+		t.Errorf("Logger of a missing section = %v at %v, want os.Stdout at %v", got, missing.GetLogLevel(), defaultLogLevel)
+	}
+}
+
+func TestRingBuffer(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.EnableRingBuffer(3)
+
+	logger.Info("first")
+	logger.Debug("second")
+	logger.Info("third")
+	logger.Trace("fourth")
+	logger.Error("fifth")
+
+	if got, want := buf.String(), "- [INFO] - first\n- [INFO] - third\n- [ERROR] - fifth\n"; got != want {
+		t.Errorf("destination = %q, want %q", got, want)
+	}
+
+	var dump bytes.Buffer
+	if err := logger.DumpRingBuffer(&dump); err != nil {
+		t.Fatalf("DumpRingBuffer() error = %v", err)
+	}
+	if got, want := dump.String(), "- [INFO] - third\n- [TRACE] - fourth\n- [ERROR] - fifth\n"; got != want {
+		t.Errorf("DumpRingBuffer() = %q, want %q", got, want)
+	}
+	if got := logger.Stats()[DEBUG] + logger.Stats()[TRACE]; got != 0 {
+		t.Errorf("count of records below the level = %d, want 0", got)
+	}
+
+	logger.EnableRingBuffer(0)
+	logger.Debug("not kept")
+	dump.Reset()
+	if err := logger.DumpRingBuffer(&dump); err != nil || dump.Len() != 0 {
+		t.Errorf("DumpRingBuffer() with the ring buffer off = %q, %v, want nothing", dump.String(), err)
+	}
+	if got := buf.String(); strings.Contains(got, "not kept") {
+		t.Errorf("destination = %q, want DEBUG suppressed again", got)
+	}
+}
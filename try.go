@@ -0,0 +1,90 @@
+package alog
+
+// The Try functions log as Trace/Debug/... do, but return the error of the destination instead of writing the record to
+// stderr, for callers which handle failed writes themselves. They return nil when the message is suppressed, by the level,
+// sampling or deduplication, and when the Logger is async, since the record is then written later.
+
+// tryMsg logs a message as logMsg does and returns the error of the destination, if any
+func (l *Logger) tryMsg(level LogLevel, msg string, objs ...interface{}) error {
+	if !l.IsEnabled(level) {
+		return nil
+	}
+	s := l.settings(level)
+	if !s.sampled() {
+		return nil
+	}
+
+	var callSite string
+	if s.callerInfo {
+		callSite = caller()
+	}
+	var fields Fields
+	if s.stackTrace {
+		fields = fields.with(stackField, stack())
+	}
+	if len(objs) != 0 && s.errorChain {
+		objs = withErrorChains(objs)
+	}
+
+	var err error
+	l.write(s, level, fields, callSite, expandMsg(msg, objs...), func(level LogLevel, b []byte) {
+		err = l.tryWriteRecord(level, b)
+	})
+	return err
+}
+
+func (l *Logger) TryTrace(msg string, objs ...interface{}) error {
+	return l.tryMsg(TRACE, msg, objs...)
+}
+
+func (l *Logger) TryDebug(msg string, objs ...interface{}) error {
+	return l.tryMsg(DEBUG, msg, objs...)
+}
+
+func (l *Logger) TryInfo(msg string, objs ...interface{}) error {
+	return l.tryMsg(INFO, msg, objs...)
+}
+
+func (l *Logger) TryNotice(msg string, objs ...interface{}) error {
+	return l.tryMsg(NOTICE, msg, objs...)
+}
+
+func (l *Logger) TryWarn(msg string, objs ...interface{}) error {
+	return l.tryMsg(WARN, msg, objs...)
+}
+
+func (l *Logger) TryError(msg string, objs ...interface{}) error {
+	return l.tryMsg(ERROR, msg, objs...)
+}
+
+func (l *Logger) TryCritical(msg string, objs ...interface{}) error {
+	return l.tryMsg(CRITICAL, msg, objs...)
+}
+
+func TryTrace(msg string, objs ...interface{}) error {
+	return std.tryMsg(TRACE, msg, objs...)
+}
+
+func TryDebug(msg string, objs ...interface{}) error {
+	return std.tryMsg(DEBUG, msg, objs...)
+}
+
+func TryInfo(msg string, objs ...interface{}) error {
+	return std.tryMsg(INFO, msg, objs...)
+}
+
+func TryNotice(msg string, objs ...interface{}) error {
+	return std.tryMsg(NOTICE, msg, objs...)
+}
+
+func TryWarn(msg string, objs ...interface{}) error {
+	return std.tryMsg(WARN, msg, objs...)
+}
+
+func TryError(msg string, objs ...interface{}) error {
+	return std.tryMsg(ERROR, msg, objs...)
+}
+
+func TryCritical(msg string, objs ...interface{}) error {
+	return std.tryMsg(CRITICAL, msg, objs...)
+}
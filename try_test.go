@@ -0,0 +1,35 @@
+package alog
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestTryError(t *testing.T) {
+	var stderr bytes.Buffer
+	defer func(out io.Writer) { fallbackOut = out }(fallbackOut)
+	fallbackOut = &stderr
+
+	logger := New(failingWriter{}, INFO)
+	err := logger.TryError("disk full %d", 1)
+	if err == nil || err.Error() != "write failed" {
+		t.Errorf("TryError() to a failing writer = %v, want the write error", err)
+	}
+	if err := logger.TryDebug("suppressed"); err != nil {
+		t.Errorf("TryDebug() below the level = %v, want nil", err)
+	}
+	if stderr.Len() != 0 {
+		t.Errorf("stderr = %q, want nothing, since the error is returned instead", stderr.String())
+	}
+
+	var buf bytes.Buffer
+	logger = New(&buf, INFO)
+	logger.SetFlags(0)
+	if err := logger.TryInfo("written %d%%", 100); err != nil {
+		t.Errorf("TryInfo() = %v, want nil", err)
+	}
+	if got, want := buf.String(), "- [INFO] - written 100%\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
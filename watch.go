@@ -0,0 +1,71 @@
+package alog
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// configPollInterval is how often the watcher started by WatchConfig checks the config file for changes
+var configPollInterval = time.Second
+
+var (
+	watchMu   sync.Mutex
+	watchStop chan struct{}
+	watchDone chan struct{}
+)
+
+// WatchConfig starts a goroutine which calls ReloadConfig whenever the modification time of the logger config file changes.
+// This allows, for example, the log level to be raised in production by editing alog.conf.
+// Calling WatchConfig while a watcher is already running has no effect. Use StopWatch to halt it.
+func WatchConfig() {
+	watchMu.Lock()
+	defer watchMu.Unlock()
+
+	if watchStop != nil {
+		return
+	}
+	watchStop, watchDone = make(chan struct{}), make(chan struct{})
+	go watchConfig(configModTime(), watchStop, watchDone)
+}
+
+// StopWatch halts the watcher started by WatchConfig and waits for it to exit. It is safe to call more than once.
+func StopWatch() {
+	watchMu.Lock()
+	defer watchMu.Unlock()
+
+	if watchStop == nil {
+		return
+	}
+	close(watchStop)
+	<-watchDone
+	watchStop, watchDone = nil, nil
+}
+
+func watchConfig(lastMod time.Time, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if modTime := configModTime(); !modTime.Equal(lastMod) {
+				lastMod = modTime
+				ReloadConfig()
+			}
+		}
+	}
+}
+
+// configModTime returns the modification time of the logger config file, or the zero time if it does not exist
+func configModTime() time.Time {
+	info, err := os.Stat(configFileName())
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,43 @@
+package alog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchConfig(t *testing.T) {
+	dir := chdirTemp(t)
+	restoreDefaultLogger(t)
+	defer func(interval time.Duration) { configPollInterval = interval }(configPollInterval)
+	configPollInterval = 10 * time.Millisecond
+
+	writeConfig(t, dir, `alog {
+    logLevel = "INFO"
+}`)
+	if err := ReloadConfig(); err != nil {
+		t.Fatalf("ReloadConfig() error = %v", err)
+	}
+
+	WatchConfig()
+	WatchConfig()
+	defer StopWatch()
+
+	writeConfig(t, dir, `alog {
+    logLevel = "WARN"
+}`)
+	future := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(dir, "alog.conf"), future, future)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for GetLogLevel() != WARN {
+		if time.Now().After(deadline) {
+			t.Fatalf("level = %v after editing alog.conf, want WARN", GetLogLevel())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	StopWatch()
+	StopWatch()
+}
@@ -0,0 +1,110 @@
+package alog
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+)
+
+// levelWriter is an io.Writer which logs each write as a message at a fixed level
+type levelWriter struct {
+	logger *Logger
+	level  LogLevel
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	msg := strings.TrimSuffix(string(p), "\n")
+	if logFunc := w.logger.logFunc(w.level); logFunc != nil {
+		logFunc(w.logger, w.level, nil, msg)
+	}
+	return len(p), nil
+}
+
+// LevelWriter returns an io.Writer which logs each write through the Logger as a message at level, with a trailing newline trimmed.
+// It allows libraries which accept an io.Writer or *log.Logger to log through alog, e.g.
+//
+//	http.Server{ErrorLog: log.New(logger.LevelWriter(alog.ERROR), "", 0)}
+//
+// With SetCallerInfo on, a record reports the code which called Write, or the *log.Logger's Print, Printf, etc.
+func (l *Logger) LevelWriter(level LogLevel) io.Writer {
+	return &levelWriter{logger: l, level: level}
+}
+
+// LevelWriter returns an io.Writer which logs each write through the default Logger as a message at level
+func LevelWriter(level LogLevel) io.Writer {
+	return std.LevelWriter(level)
+}
+
+// prefixWriter is an io.Writer which logs each line written to it at the level named by its "[LEVEL]" prefix, if any
+type prefixWriter struct {
+	logger       *Logger
+	defaultLevel LogLevel
+	// mu guards partial, the start of a line whose newline has not been written yet
+	mu      sync.Mutex
+	partial []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i == -1 {
+			break
+		}
+		w.logLine(string(bytes.TrimSuffix(w.partial[:i], []byte("\r"))))
+		w.partial = w.partial[i+1:]
+	}
+	if len(w.partial) == 0 {
+		w.partial = nil
+	}
+	return len(p), nil
+}
+
+// Close logs the last line, if it was not terminated by a newline
+func (w *prefixWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) != 0 {
+		w.logLine(string(w.partial))
+		w.partial = nil
+	}
+	return nil
+}
+
+// logLine logs a line at the level of its "[LEVEL] " prefix, which is removed, or else at the default level
+func (w *prefixWriter) logLine(line string) {
+	level, msg := w.defaultLevel, line
+	if rest := strings.TrimLeft(line, " \t"); strings.HasPrefix(rest, "[") {
+		if end := strings.IndexByte(rest, ']'); end != -1 {
+			if parsed, err := ParseLevel(rest[1:end]); err == nil && parsed < OFF {
+				level, msg = parsed, strings.TrimLeft(rest[end+1:], " ")
+			}
+		}
+	}
+	if logFunc := w.logger.logFunc(level); logFunc != nil {
+		logFunc(w.logger, level, nil, msg)
+	}
+}
+
+// PrefixParsingWriter returns an io.Writer which logs each line written to it through the Logger, at the level named by
+// the "[LEVEL]" tag it starts with, e.g. "[ERROR] disk full", or at defaultLevel for lines without one. The tag is removed
+// from the message and is matched as ParseLevel does. It suits the output of subprocesses which tag their lines, e.g.
+//
+//	cmd.Stdout = logger.PrefixParsingWriter(alog.INFO)
+//
+// Lines may be split across writes. The writer also implements io.Closer, whose Close logs a last line which was not
+// terminated by a newline. With SetCallerInfo on, a record reports the code which wrote the newline ending its line.
+func (l *Logger) PrefixParsingWriter(defaultLevel LogLevel) io.Writer {
+	return &prefixWriter{logger: l, defaultLevel: defaultLevel}
+}
+
+// PrefixParsingWriter returns an io.Writer which logs each line written to it through the default Logger, at the level
+// of its "[LEVEL]" tag or at defaultLevel
+func PrefixParsingWriter(defaultLevel LogLevel) io.Writer {
+	return std.PrefixParsingWriter(defaultLevel)
+}
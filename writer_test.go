@@ -0,0 +1,100 @@
+package alog
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLevelWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+
+	errorLog := log.New(logger.LevelWriter(ERROR), "", 0)
+	errorLog.Printf("http: TLS handshake error from %s", "10.0.0.1:5555")
+	logger.LevelWriter(DEBUG).Write([]byte("suppressed\n"))
+
+	if got, want := buf.String(), "- [ERROR] - http: TLS handshake error from 10.0.0.1:5555\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestLevelWriterCallerInfo(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetCallerInfo(true)
+
+	w := logger.LevelWriter(ERROR)
+	errorLog := log.New(w, "", 0)
+	_, _, line, _ := runtime.Caller(0)
+	errorLog.Printf("through %s", "log")
+	errorLog.Println("through log.Println")
+	w.Write([]byte("direct\n"))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for i, want := range []string{
+		fmt.Sprintf("writer_test.go:%d - [ERROR] - through log", line+1),
+		fmt.Sprintf("writer_test.go:%d - [ERROR] - through log.Println", line+2),
+		fmt.Sprintf("writer_test.go:%d - [ERROR] - direct", line+3),
+	} {
+		if i >= len(lines) || lines[i] != want {
+			t.Errorf("output = %q, want line %d to be %q", buf.String(), i, want)
+		}
+	}
+}
+
+func TestPrefixParsingWriter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, DEBUG)
+	logger.SetFlags(0)
+
+	w := logger.PrefixParsingWriter(INFO)
+	io.WriteString(w, "[ERROR] disk full\nno tag\n  [warning] low mem")
+	io.WriteString(w, "ory\r\n[TRACE] suppressed\n[OFF] not a level\n[BOGUS] kept as is\n[debug]tight\n")
+	io.WriteString(w, "[CRITICAL] unterminated")
+	w.(io.Closer).Close()
+
+	want := "- [ERROR] - disk full\n" +
+		"- [INFO] - no tag\n" +
+		"- [WARN] - low memory\n" +
+		"- [INFO] - [OFF] not a level\n" +
+		"- [INFO] - [BOGUS] kept as is\n" +
+		"- [DEBUG] - tight\n" +
+		"- [CRITICAL] - unterminated\n"
+	if got := buf.String(); got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
+
+func TestPrefixParsingWriterCallerInfo(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, INFO)
+	logger.SetFlags(0)
+	logger.SetCallerInfo(true)
+
+	w := logger.PrefixParsingWriter(INFO)
+	_, _, line, _ := runtime.Caller(0)
+	w.Write([]byte("direct\n"))
+	fmt.Fprintln(w, "[WARN] through fmt")
+	io.WriteString(w, "through io\n")
+	io.WriteString(w, "unterminated")
+	w.(io.Closer).Close()
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	for i, want := range []string{
+		fmt.Sprintf("writer_test.go:%d - [INFO] - direct", line+1),
+		fmt.Sprintf("writer_test.go:%d - [WARN] - through fmt", line+2),
+		fmt.Sprintf("writer_test.go:%d - [INFO] - through io", line+3),
+		fmt.Sprintf("writer_test.go:%d - [INFO] - unterminated", line+5),
+	} {
+		if i >= len(lines) || lines[i] != want {
+			t.Errorf("output = %q, want line %d to be %q", buf.String(), i, want)
+		}
+	}
+}